@@ -3,10 +3,13 @@ package code_generator
 import (
 	"atollk/terraform-api-provider-generator/internal/oas_parser"
 	"atollk/terraform-api-provider-generator/internal/provider_spec"
+	"fmt"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/casing"
 )
 
 // RenderSpec generates Terraform provider code from the given provider and API specifications.
@@ -17,10 +20,41 @@ func RenderSpec(
 	apiSpec oas_parser.OADoc,
 ) error {
 	// Prepare input data
+	specDefaults := providerSpec.GlobalDefaults
+	if specDefaults == nil {
+		specDefaults = &provider_spec.GlobalDefaults{}
+	}
+	securitySchemes, err := getSecuritySchemes(apiSpec)
+	if err != nil {
+		return errors.Errorf("cannot read security schemes: %w", err)
+	}
+	reservedAttributes := slices.Clone(builtinProviderAttributes)
+	for _, scheme := range securitySchemes {
+		if scheme.HasAttribute() {
+			reservedAttributes = append(reservedAttributes, scheme.AttributeName())
+		}
+	}
+	serverVariables, err := getServerVariables(apiSpec, reservedAttributes)
+	if err != nil {
+		return errors.Errorf("cannot read server variables: %w", err)
+	}
+	identity := providerSpec.Provider
+	if identity.RegistryHostname == "" {
+		identity.RegistryHostname = "registry.terraform.io"
+	}
+	if identity.ModulePath == "" {
+		identity.ModulePath = fmt.Sprintf("github.com/%s/terraform-provider-%s", identity.Namespace, casing.Kebab(identity.Name))
+	}
 	providerInfo := ProviderInfo{
-		Author:       "foo",
-		Name:         "pet_store",
-		SpecDefaults: providerSpec.GlobalDefaults,
+		Name:             identity.Name,
+		Namespace:        identity.Namespace,
+		RegistryHostname: identity.RegistryHostname,
+		ModulePath:       identity.ModulePath,
+		Description:      identity.Description,
+		SpecDefaults:     specDefaults,
+		SecuritySchemes:  securitySchemes,
+		ServerVariables:  serverVariables,
+		APISpec:          apiSpec,
 	}
 	var resources []ResourceInfo
 	var dataSources []DataSourceInfo
@@ -51,6 +85,7 @@ func RenderSpec(
 		getMainGoTemplate(&providerInfo),
 		getGoModTemplate(&providerInfo),
 		getSharedGoTemplate(),
+		getSharedTestGoTemplate(),
 		getOasJsonTemplate(apiSpec),
 		getProviderGoTemplate(&providerInfo, resources, dataSources),
 	}
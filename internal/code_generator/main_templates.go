@@ -5,18 +5,60 @@ import (
 	"atollk/terraform-api-provider-generator/internal/provider_spec"
 	"bytes"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"text/template"
 
+	"github.com/cockroachdb/errors"
 	"github.com/danielgtaylor/casing"
 )
 
+// builtinProviderAttributes lists the provider attributes that are always generated.
+// Attributes derived from security schemes and server variables must not collide with them.
+var builtinProviderAttributes = []string{
+	"base_url",
+	"headers",
+	"username",
+	"password",
+	"oauth_client_id",
+	"oauth_client_secret",
+	"oauth_token_endpoint",
+	"oauth_scopes",
+	"cert_file",
+	"key_file",
+	"cert_string",
+	"key_string",
+	"root_ca_file",
+	"root_ca_string",
+	"insecure",
+	"retry_max_attempts",
+	"retry_min_backoff",
+	"retry_max_backoff",
+	"retry_status_codes",
+	"retry_non_idempotent",
+	"rate_limit",
+	"timeout",
+	"use_cookies",
+}
+
 // ProviderInfo contains metadata and configuration for a Terraform provider.
 type ProviderInfo struct {
-	Name         string
-	Author       string
-	SpecDefaults *provider_spec.GlobalDefaults
+	Name             string
+	Namespace        string
+	RegistryHostname string
+	ModulePath       string
+	Description      string
+
+	SpecDefaults    *provider_spec.GlobalDefaults
+	SecuritySchemes []SecuritySchemeInfo
+	ServerVariables []ServerVariableInfo
+	APISpec         oas_parser.OADoc
+
+	// warned holds the keys of the warnings already logged during generation.
+	warned map[string]bool
 }
 
 // NameKebab returns the provider name in kebab-case format.
@@ -24,9 +66,139 @@ func (p *ProviderInfo) NameKebab() string {
 	return casing.Kebab(p.Name)
 }
 
-// NameCaps returns the provider name in uppercase.
+// RegistryAddress returns the address of the provider in the registry, such as registry.terraform.io/namespace/name.
+func (p *ProviderInfo) RegistryAddress() string {
+	return fmt.Sprintf("%s/%s/%s", p.RegistryHostname, p.Namespace, p.NameKebab())
+}
+
+// NameCaps returns the provider name in uppercase snake_case format, as used for environment variable names.
 func (p *ProviderInfo) NameCaps() string {
-	return strings.ToUpper(p.NameKebab())
+	return strings.ToUpper(casing.Snake(p.Name))
+}
+
+// OAuthDefaults returns the OAuth client credentials configured in the spec defaults, or an empty configuration if there are none.
+func (p *ProviderInfo) OAuthDefaults() provider_spec.OauthClientCredentials {
+	if p.SpecDefaults.OauthClientCredentials == nil {
+		return provider_spec.OauthClientCredentials{}
+	}
+	return *p.SpecDefaults.OauthClientCredentials
+}
+
+// RenderOAuthEndpointParams generates a url.Values expression with the OAuth endpoint parameters from the spec defaults.
+func (p *ProviderInfo) RenderOAuthEndpointParams() string {
+	endpointParams := p.OAuthDefaults().EndpointParams
+	if endpointParams == nil {
+		return "url.Values{}"
+	}
+	result := strings.Builder{}
+	result.WriteString("url.Values{\n")
+	for _, key := range slices.Sorted(maps.Keys(endpointParams.OtherProps)) {
+		var values []string
+		switch value := endpointParams.OtherProps[key].(type) {
+		case []any:
+			for _, item := range value {
+				values = append(values, fmt.Sprintf("%v", item))
+			}
+		default:
+			values = append(values, fmt.Sprintf("%v", value))
+		}
+		result.WriteString(fmt.Sprintf("%q: %#v,\n", key, values))
+	}
+	result.WriteString("}")
+	return result.String()
+}
+
+// DefaultTimeout returns the request timeout in seconds from the spec defaults, or 30 if there is none.
+func (p *ProviderInfo) DefaultTimeout() float64 {
+	if p.SpecDefaults.Timeout == nil {
+		return 30
+	}
+	return *p.SpecDefaults.Timeout
+}
+
+// DefaultBaseURL returns the base URL from the spec defaults. If the URI has no scheme, https is assumed.
+// Without a URI in the spec defaults, the URL of the first server in the OpenAPI document is used if it is absolute.
+// It may contain server variables.
+func (p *ProviderInfo) DefaultBaseURL() string {
+	uri := p.SpecDefaults.Uri
+	if uri == "" {
+		return getDefaultServerURL(p.APISpec)
+	}
+	if strings.Contains(uri, "://") {
+		return uri
+	}
+	return "https://" + uri
+}
+
+// TestMethod returns the HTTP method of the connectivity check, which is the global read method.
+func (p *ProviderInfo) TestMethod() string {
+	if p.SpecDefaults.ReadMethod == "" {
+		return "GET"
+	}
+	return p.SpecDefaults.ReadMethod
+}
+
+// RenderTestSecurityRequirements generates the security requirements of the connectivity check.
+func (p *ProviderInfo) RenderTestSecurityRequirements() string {
+	return renderSecurityRequirements(getOperationSecurityRequirements(p.APISpec, p.SpecDefaults.TestPath, p.TestMethod()))
+}
+
+// renderOperationBaseURL generates an expression for the base URL of an operation that the provider performs itself.
+// Servers declared for the operation or its path override the base URL configured in the provider.
+func (p *ProviderInfo) renderOperationBaseURL(path string, operation string) string {
+	serverURL := getOperationServerURL(p.APISpec, path, operation)
+	if serverURL == "" {
+		return "baseUrl"
+	}
+	return fmt.Sprintf("resolveServerURL(baseUrl, %q, serverVariables)", serverURL)
+}
+
+// RenderTestBaseURL generates an expression for the base URL of the connectivity check.
+func (p *ProviderInfo) RenderTestBaseURL() string {
+	return p.renderOperationBaseURL(p.SpecDefaults.TestPath, p.TestMethod())
+}
+
+// RenderLoginBaseURL generates an expression for the base URL of the login operation.
+func (p *ProviderInfo) RenderLoginBaseURL() string {
+	return p.renderOperationBaseURL(p.SpecDefaults.Login.Path, p.LoginMethod())
+}
+
+// RenderRetryPolicy generates a RetryPolicy expression from the global retry settings.
+func (p *ProviderInfo) RenderRetryPolicy() string {
+	return renderRetryPolicy((&provider_spec.ResourceSchema{}).GetRetryPolicy(p.SpecDefaults))
+}
+
+// LoginMethod returns the HTTP method of the login operation from the spec defaults.
+func (p *ProviderInfo) LoginMethod() string {
+	if p.SpecDefaults.Login.Method == "" {
+		return "POST"
+	}
+	return p.SpecDefaults.Login.Method
+}
+
+// RenderLoginBody generates a string literal with the JSON body of the login operation from the spec defaults.
+func (p *ProviderInfo) RenderLoginBody() (string, error) {
+	body := map[string]any{"username": "{username}", "password": "{password}"}
+	if p.SpecDefaults.Login.Body != nil {
+		body = p.SpecDefaults.Login.Body.OtherProps
+	}
+	result, err := json.Marshal(body)
+	if err != nil {
+		return "", errors.Errorf("could not render login body: %w", err)
+	}
+	return fmt.Sprintf("%q", result), nil
+}
+
+// RenderDefaultHeaders generates the entries of a map[string]string literal with the headers from the spec defaults.
+func (p *ProviderInfo) RenderDefaultHeaders() string {
+	if p.SpecDefaults.Headers == nil {
+		return ""
+	}
+	result := strings.Builder{}
+	for _, name := range slices.Sorted(maps.Keys(p.SpecDefaults.Headers.OtherProps)) {
+		result.WriteString(fmt.Sprintf("\n%q: %q,", name, p.SpecDefaults.Headers.OtherProps[name]))
+	}
+	return result.String()
 }
 
 type ResourceDataSourceInfo interface {
@@ -169,6 +341,14 @@ func getSharedGoTemplate() templateRenderer {
 	return renderTemplateAs("internal/provider/shared.go", sharedGoTemplate, nil)
 }
 
+//go:embed templates/main/internal/provider/shared_test.go.tmpl
+var sharedTestGoTemplate string
+
+// getSharedTestGoTemplate creates a template renderer for the shared_test.go file, which tests the code in shared.go.
+func getSharedTestGoTemplate() templateRenderer {
+	return renderTemplateAs("internal/provider/shared_test.go", sharedTestGoTemplate, nil)
+}
+
 // getOasJsonTemplate creates a template renderer for the OpenAPI specification JSON file.
 func getOasJsonTemplate(oadoc oas_parser.OADoc) templateRenderer {
 	renderFunc := func() ([]byte, error) {
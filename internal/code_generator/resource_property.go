@@ -3,12 +3,20 @@ package code_generator
 import (
 	"atollk/terraform-api-provider-generator/internal/provider_spec"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/danielgtaylor/casing"
 	"github.com/kaptinlin/messageformat-go/pkg/logger"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
 )
 
 // augmentedPropertySchema represents a resource property with metadata about where it appears in request/response bodies.
@@ -17,6 +25,26 @@ type augmentedPropertySchema struct {
 	Schema              *base.Schema
 	containedInBodyFlag int
 	parent              *resourceTemplateRenderer
+	// owner is the object property this property is nested in, or nil for properties of the resource itself.
+	owner *augmentedPropertySchema
+	// required is true if the property is in the required list of its owner, or of the create request body for properties of the resource itself.
+	required bool
+	// properties holds the nested properties of an object property.
+	properties []augmentedPropertySchema
+	// items describes the items of a list or set property.
+	items *augmentedPropertySchema
+	// isItem is true if the property describes the items of its owner.
+	isItem bool
+	// isVariant is true if the property is a variant of its polymorphic owner.
+	isVariant bool
+	// discriminatorValue is the value of the discriminator property of the owner that selects this variant.
+	discriminatorValue string
+	// reference is the $ref through which the schema of the property is declared, or an empty string if it is declared inline.
+	reference string
+	// topSchemaType caches the result of GetTopSchemaType, which is resolved on first use.
+	topSchemaType string
+	// nestingDepth caches the result of depth, or is 0 before it is first computed.
+	nestingDepth int
 }
 
 const (
@@ -31,7 +59,12 @@ const (
 	propertyTypeInt    = "integer"
 	propertyTypeFloat  = "float"
 	propertyTypeString = "string"
+	propertyTypeObject = "object"
+	propertyTypeList   = "list"
+	propertyTypeSet    = "set"
+	propertyTypeMap    = "map"
 	propertyTypeAny    = "any"
+	propertyTypeJSON   = "json"
 )
 
 // mapJsonSchemaToInternal converts a JSON schema type to an internal property type constant.
@@ -48,18 +81,276 @@ func mapJsonSchemaToInternal(jsonSchemaType string) string {
 	case "string":
 		return propertyTypeString
 	case "object":
-		// TODO
-		return propertyTypeAny
+		return propertyTypeObject
 	case "array":
-		// TODO
-		return propertyTypeAny
+		return propertyTypeList
 	default:
 		return propertyTypeAny
 	}
 }
 
 // GetTopSchemaType returns the primary type of the property, handling nullable types appropriately.
+// Objects without declared properties are maps if their values are declared, and have no fixed structure otherwise.
+// Arrays are lists, or sets if their items are unique.
+// Collections whose items contain values of any type are treated as any type as well.
+// Polymorphic properties with object variants are objects with one attribute per variant.
+// Objects nested too deeply have the fallback type returned by nestingFallback.
+// The type is resolved once per property, since it is needed by nearly every generated piece of code.
 func (p *augmentedPropertySchema) GetTopSchemaType() string {
+	if p.topSchemaType == "" {
+		p.topSchemaType = p.resolveTopSchemaType()
+	}
+	return p.topSchemaType
+}
+
+// resolveTopSchemaType determines the type returned by GetTopSchemaType.
+func (p *augmentedPropertySchema) resolveTopSchemaType() string {
+	if p.exceedsNestingDepth() {
+		if p.nestingFallback() == provider_spec.NestingFallbackJSONString {
+			return propertyTypeJSON
+		}
+		return propertyTypeAny
+	}
+	if p.isUnion() {
+		return propertyTypeObject
+	}
+	schemaType := p.getDeclaredSchemaType()
+	switch schemaType {
+	case propertyTypeObject:
+		if orderedmap.Len(p.Schema.Properties) == 0 {
+			item, err := p.newItem()
+			if err != nil {
+				return propertyTypeMap
+			}
+			if item == nil || item.containsDynamic() {
+				return propertyTypeAny
+			}
+			return propertyTypeMap
+		}
+	case propertyTypeList:
+		item, err := p.newItem()
+		if err != nil {
+			return schemaType
+		}
+		if item == nil || item.containsDynamic() {
+			return propertyTypeAny
+		}
+		if p.Schema.UniqueItems != nil && *p.Schema.UniqueItems {
+			return propertyTypeSet
+		}
+	}
+	return schemaType
+}
+
+// isUnion returns true if the property is polymorphic and all its variants are objects with declared properties.
+// Invalid variants are reported when resolving the nested properties.
+func (p *augmentedPropertySchema) isUnion() bool {
+	if len(p.Schema.OneOf) == 0 && len(p.Schema.AnyOf) == 0 {
+		return false
+	}
+	variants, err := getUnionVariants(p.Schema)
+	if err != nil {
+		return true
+	}
+	for _, variant := range variants {
+		declared := augmentedPropertySchema{Schema: variant.Schema}
+		if declared.getDeclaredSchemaType() != propertyTypeObject || orderedmap.Len(variant.Schema.Properties) == 0 {
+			return false
+		}
+	}
+	return len(variants) > 0
+}
+
+// hasNestedAttributes returns true if the property is an object with declared properties or a polymorphic property, which have nested attributes.
+func (p *augmentedPropertySchema) hasNestedAttributes() bool {
+	return p.isUnion() || (p.getDeclaredSchemaType() == propertyTypeObject && orderedmap.Len(p.Schema.Properties) > 0)
+}
+
+// depth returns the level of nesting of the attribute of the property, starting at 1 for properties of the resource itself.
+// Items are at the level of their collection.
+func (p *augmentedPropertySchema) depth() int {
+	if p.nestingDepth == 0 {
+		switch {
+		case p.owner == nil:
+			p.nestingDepth = 1
+		case p.isItem:
+			p.nestingDepth = p.owner.depth()
+		default:
+			p.nestingDepth = p.owner.depth() + 1
+		}
+	}
+	return p.nestingDepth
+}
+
+// exceedsNestingDepth returns true if the nested attributes of the property would be nested deeper than allowed by the provider spec.
+// This bounds the attributes generated for recursive schemas.
+func (p *augmentedPropertySchema) exceedsNestingDepth() bool {
+	maxDepth := p.parent.ResourceInfo.ResourceSpec().GetMaxNestingDepth(p.parent.ProviderInfo.SpecDefaults)
+	return p.depth() >= maxDepth && p.hasNestedAttributes()
+}
+
+// nestingFallback returns the fallback type of the property if it is nested too deeply, which is chosen in the provider spec.
+// Objects in collections are always encoded as JSON strings, since collections cannot contain Dynamic values;
+// a Dynamic fallback would turn every collection containing the object into a Dynamic attribute as a whole.
+func (p *augmentedPropertySchema) nestingFallback() string {
+	for nested := p; nested != nil; nested = nested.owner {
+		if nested.isItem {
+			return provider_spec.NestingFallbackJSONString
+		}
+	}
+	return p.parent.ResourceInfo.ResourceSpec().GetNestingFallback(p.parent.ProviderInfo.SpecDefaults)
+}
+
+// recursiveReference returns the schema reference through which the property, or an object it is nested in, is nested in itself.
+// It returns an empty string if the property is not part of a cycle.
+func (p *augmentedPropertySchema) recursiveReference() string {
+	for nested := p; nested != nil; nested = nested.owner {
+		if nested.reference == "" {
+			continue
+		}
+		for owner := nested.owner; owner != nil; owner = owner.owner {
+			if owner.reference == nested.reference {
+				return nested.reference
+			}
+		}
+	}
+	return ""
+}
+
+// reportNestingFallback warns that the property is generated with the fallback type because it is nested too deeply.
+// Cycles are reported once per schema, since every path through them ends in a fallback.
+func (p *augmentedPropertySchema) reportNestingFallback() {
+	maxDepth := p.parent.ResourceInfo.ResourceSpec().GetMaxNestingDepth(p.parent.ProviderInfo.SpecDefaults)
+	fallback := p.nestingFallback()
+	if reference := p.recursiveReference(); reference != "" {
+		p.parent.warnOnce(reference, fmt.Sprintf(
+			"schema '%s' of resource '%s' is recursive; objects in it nested deeper than %d levels, such as '%s', are generated as %s attributes",
+			reference, p.parent.ResourceInfo.Name(), maxDepth, p.path(), fallback,
+		))
+		return
+	}
+	p.parent.warnOnce(p.path(), fmt.Sprintf(
+		"property '%s' of resource '%s' is nested deeper than %d levels; it is generated as a %s attribute",
+		p.path(), p.parent.ResourceInfo.Name(), maxDepth, fallback,
+	))
+}
+
+// discriminatorProperty returns the name of the property that selects the variant of a polymorphic property, or an empty string if there is none.
+func (p *augmentedPropertySchema) discriminatorProperty() string {
+	if p.Schema.Discriminator == nil {
+		return ""
+	}
+	return p.Schema.Discriminator.PropertyName
+}
+
+// isCollection returns true if the property is a list, a set or a map.
+func (p *augmentedPropertySchema) isCollection() bool {
+	schemaType := p.GetTopSchemaType()
+	return schemaType == propertyTypeList || schemaType == propertyTypeSet || schemaType == propertyTypeMap
+}
+
+// newItem creates the property describing the items of an array property or the values of a map property.
+// It returns nil if the items are not declared.
+// Errors in the schema of the items are deferred to resolveNestedProperties, so that they are not mistaken for items of any type.
+func (p *augmentedPropertySchema) newItem() (*augmentedPropertySchema, error) {
+	var itemSchemaProxy *base.SchemaProxy
+	switch p.getDeclaredSchemaType() {
+	case propertyTypeList:
+		if p.Schema.Items != nil && p.Schema.Items.IsA() {
+			itemSchemaProxy = p.Schema.Items.A
+		}
+	case propertyTypeObject:
+		itemSchemaProxy = p.mapValueSchema()
+	}
+	if itemSchemaProxy == nil {
+		return nil, nil
+	}
+	itemSchema, err := buildSchema(itemSchemaProxy)
+	if err != nil {
+		return nil, errors.Errorf("could not get schema for items of %s: %w", p.path(), err)
+	}
+	return &augmentedPropertySchema{Name: p.Name, Schema: itemSchema, parent: p.parent, owner: p, isItem: true, reference: itemSchemaProxy.GetReference()}, nil
+}
+
+// mapValueSchema returns the schema of the values of an object, which is declared by additionalProperties.
+// Without additional properties, the schema of a single entry in patternProperties is used instead.
+func (p *augmentedPropertySchema) mapValueSchema() *base.SchemaProxy {
+	additionalProperties := p.Schema.AdditionalProperties
+	if additionalProperties != nil && additionalProperties.IsA() {
+		return additionalProperties.A
+	}
+	if additionalProperties != nil && additionalProperties.IsB() && additionalProperties.B {
+		return nil
+	}
+	if orderedmap.Len(p.Schema.PatternProperties) == 1 {
+		return p.Schema.PatternProperties.First().Value()
+	}
+	return nil
+}
+
+// mapKeyPattern returns the pattern that all keys of a map property match, or an empty string if there is none.
+// Keys are only restricted if the values are declared by patternProperties.
+func (p *augmentedPropertySchema) mapKeyPattern() string {
+	additionalProperties := p.Schema.AdditionalProperties
+	if additionalProperties != nil && (additionalProperties.IsA() || additionalProperties.B) {
+		return ""
+	}
+	if orderedmap.Len(p.Schema.PatternProperties) != 1 {
+		return ""
+	}
+	return p.Schema.PatternProperties.First().Key()
+}
+
+// containsDynamic returns true if the property is, or contains, a property of any type.
+// Values of any type cannot be elements of lists, sets or maps.
+// Invalid schemas are reported when resolving the nested properties, not here.
+func (p *augmentedPropertySchema) containsDynamic() bool {
+	switch p.GetTopSchemaType() {
+	case propertyTypeAny:
+		return true
+	case propertyTypeObject:
+		if p.isUnion() {
+			variants, err := getUnionVariants(p.Schema)
+			if err != nil {
+				return false
+			}
+			for _, variant := range variants {
+				nested := augmentedPropertySchema{Name: variant.Name, Schema: variant.Schema, parent: p.parent, owner: p, isVariant: true}
+				if nested.containsDynamic() {
+					return true
+				}
+			}
+			return false
+		}
+		for propertyName, propertySchemaProxy := range p.Schema.Properties.FromOldest() {
+			propertySchema, err := buildSchema(propertySchemaProxy)
+			if err != nil {
+				return false
+			}
+			nested := augmentedPropertySchema{Name: propertyName, Schema: propertySchema, parent: p.parent, owner: p}
+			if nested.containsDynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsObject returns true if the property is an object or a collection of objects, which need generated conversion code.
+// Objects encoded as JSON strings need conversion code as well.
+func (p *augmentedPropertySchema) containsObject() bool {
+	switch p.GetTopSchemaType() {
+	case propertyTypeObject, propertyTypeJSON:
+		return true
+	case propertyTypeList, propertyTypeSet, propertyTypeMap:
+		return p.items.containsObject()
+	default:
+		return false
+	}
+}
+
+// getDeclaredSchemaType maps the declared JSON schema type of the property to an internal property type.
+func (p *augmentedPropertySchema) getDeclaredSchemaType() string {
 	if slices.Contains(p.Schema.Type, "null") {
 		if len(p.Schema.Type) != 2 {
 			return propertyTypeAny
@@ -78,12 +369,423 @@ func (p *augmentedPropertySchema) GetTopSchemaType() string {
 	}
 }
 
+// resolveNestedProperties collects the nested properties of an object property and the items of a collection property, recursively.
+// The recursion ends at the maximum nesting depth, which also ends cycles in the schema.
+func (p *augmentedPropertySchema) resolveNestedProperties() error {
+	if p.exceedsNestingDepth() {
+		p.reportNestingFallback()
+		return nil
+	}
+	if p.isCollection() {
+		items, err := p.newItem()
+		if err != nil {
+			return err
+		}
+		p.items = items
+		return p.items.resolveNestedProperties()
+	}
+	if p.GetTopSchemaType() != propertyTypeObject {
+		return nil
+	}
+	p.properties = nil
+	if p.isUnion() {
+		variants, err := getUnionVariants(p.Schema)
+		if err != nil {
+			return errors.Errorf("could not get variants of %s: %w", p.path(), err)
+		}
+		for _, variant := range variants {
+			nested := augmentedPropertySchema{
+				Name:               variant.Name,
+				Schema:             variant.Schema,
+				parent:             p.parent,
+				owner:              p,
+				isVariant:          true,
+				discriminatorValue: variant.DiscriminatorValue,
+				reference:          variant.Reference,
+			}
+			if err := nested.resolveNestedProperties(); err != nil {
+				return err
+			}
+			p.properties = append(p.properties, nested)
+		}
+		return nil
+	}
+	for propertyName, propertySchemaProxy := range p.Schema.Properties.FromOldest() {
+		if p.isVariant && propertyName == p.owner.discriminatorProperty() {
+			continue
+		}
+		propertySchema, err := buildSchema(propertySchemaProxy)
+		if err != nil {
+			return errors.Errorf("could not get schema for property %s of %s: %w", propertyName, p.path(), err)
+		}
+		nested := augmentedPropertySchema{
+			Name:      propertyName,
+			Schema:    propertySchema,
+			parent:    p.parent,
+			owner:     p,
+			required:  slices.Contains(p.Schema.Required, propertyName),
+			reference: propertySchemaProxy.GetReference(),
+		}
+		if err := nested.resolveNestedProperties(); err != nil {
+			return err
+		}
+		p.properties = append(p.properties, nested)
+	}
+	return nil
+}
+
+// path returns the dot-separated path of the property within the resource.
+// Items share the path of their collection.
+func (p *augmentedPropertySchema) path() string {
+	if p.isItem {
+		return p.owner.path()
+	}
+	if p.owner == nil {
+		return p.Name
+	}
+	return fmt.Sprintf("%s.%s", p.owner.path(), p.Name)
+}
+
+// AttributeName returns the name of the Terraform attribute for this property.
+func (p *augmentedPropertySchema) AttributeName() string {
+	return casing.Snake(p.Name)
+}
+
+// ModelTypeName returns the name of the model struct generated for an object property.
+// Objects that are items of a collection are named after the collection.
+func (p *augmentedPropertySchema) ModelTypeName() string {
+	if p.isItem {
+		return p.owner.ModelTypeName()
+	}
+	if p.owner == nil {
+		return fmt.Sprintf("%s%sModel", p.parent.ResourceInfo.MainTypeName(), casing.Camel(p.Name))
+	}
+	return fmt.Sprintf("%s%sModel", strings.TrimSuffix(p.owner.ModelTypeName(), "Model"), casing.Camel(p.Name))
+}
+
+// conversionFuncName returns the name of a function generated for an object property, such as its conversion from or to a body.
+func (p *augmentedPropertySchema) conversionFuncName(suffix string) string {
+	return fmt.Sprintf("%s%s", casing.LowerCamel(strings.TrimSuffix(p.ModelTypeName(), "Model")), suffix)
+}
+
+// isReadOnly returns true if the property or any object it is nested in is read-only.
+func (p *augmentedPropertySchema) isReadOnly() bool {
+	readOnly := p.Schema.ReadOnly != nil && *p.Schema.ReadOnly
+	return readOnly || (p.owner != nil && p.owner.isReadOnly())
+}
+
+// isWriteOnly returns true if the property or any object it is nested in is write-only.
+func (p *augmentedPropertySchema) isWriteOnly() bool {
+	writeOnly := p.Schema.WriteOnly != nil && *p.Schema.WriteOnly
+	return writeOnly || (p.owner != nil && p.owner.isWriteOnly())
+}
+
+// isInRequest returns true if the property is sent to the API in create or update requests.
+func (p *augmentedPropertySchema) isInRequest() bool {
+	return p.isInRequestOf(augmentedPropertySchemaCreateRequest | augmentedPropertySchemaUpdateRequest)
+}
+
+// isInRequestOf returns true if the property is sent to the API in any of the given requests, which are augmentedPropertySchema*Request flags.
+func (p *augmentedPropertySchema) isInRequestOf(requests int) bool {
+	if p.isReadOnly() {
+		return false
+	}
+	if p.owner != nil {
+		return p.owner.isInRequestOf(requests)
+	}
+	return p.containedInBodyFlag&requests != 0
+}
+
+// isInResponse returns true if the API returns the property in create or update responses.
+func (p *augmentedPropertySchema) isInResponse() bool {
+	if p.isWriteOnly() {
+		return false
+	}
+	if p.owner != nil {
+		return p.owner.isInResponse()
+	}
+	return p.containedInBodyFlag&(augmentedPropertySchemaCreateResponse|augmentedPropertySchemaUpdateResponse) != 0
+}
+
+// attributeSettings returns the settings of the attribute of the property in the provider spec.
+func (p *augmentedPropertySchema) attributeSettings() provider_spec.AttributeSettings {
+	return p.parent.ResourceInfo.ResourceSpec().Attributes[p.path()]
+}
+
+// IsRequired returns true if the property must be set in the configuration.
+// Properties of the resource are required if the create request requires them, nested properties if the object containing them requires them.
+// Data sources only require the ID attribute, which identifies the object to read.
+func (p *augmentedPropertySchema) IsRequired() bool {
+	if p.parent.IsDataSource {
+		return p.owner == nil && p.Name == p.parent.idAttribute()
+	}
+	if required := p.attributeSettings().Required; required != nil {
+		return *required
+	}
+	return p.required && p.isInRequest()
+}
+
+// IsOptional returns true if the property may be set in the configuration, but is not required.
+func (p *augmentedPropertySchema) IsOptional() bool {
+	if p.parent.IsDataSource {
+		return false
+	}
+	if optional := p.attributeSettings().Optional; optional != nil {
+		return *optional
+	}
+	return !p.IsRequired() && p.isInRequest()
+}
+
+// IsComputed returns true if the value of the property may be filled in by the API.
+// Properties that cannot be set in the configuration are always computed.
+// Variants are only filled in if they are chosen in the configuration, unless they cannot be set.
+func (p *augmentedPropertySchema) IsComputed() bool {
+	if p.parent.IsDataSource {
+		return !p.IsRequired()
+	}
+	if computed := p.attributeSettings().Computed; computed != nil {
+		return *computed
+	}
+	if p.isVariant {
+		return !p.isInRequest()
+	}
+	return !p.IsRequired() && (p.isInResponse() || !p.isInRequest() || p.hasDefault())
+}
+
+// hasDefault returns true if the attribute of the property takes the default value declared in the OpenAPI document when it is not set.
+// Only properties sent to the API have defaults, since the API decides the values of the others.
+// Data sources have no defaults, and defaults can be disabled per attribute in the provider spec.
+func (p *augmentedPropertySchema) hasDefault() bool {
+	if p.parent.IsDataSource || p.isItem || p.isVariant || p.Schema.Default == nil || !p.isInRequest() {
+		return false
+	}
+	if useDefault := p.attributeSettings().UseDefault; useDefault != nil && !*useDefault {
+		return false
+	}
+	return p.defaultValue() != nil
+}
+
+// defaultValue returns the default value declared for the property in its representation in a body, with numbers as json.Number.
+// It returns nil if there is no default, or if the default does not fit the attribute of the property, which is reported.
+func (p *augmentedPropertySchema) defaultValue() any {
+	var value any
+	if err := p.Schema.Default.Decode(&value); err != nil {
+		p.parent.warnOnce("default/"+p.path(), fmt.Sprintf("default value of property '%s' cannot be decoded and is ignored: %v", p.path(), err))
+		return nil
+	}
+	value = normalizeBodyNumbers(value)
+	if value != nil && !p.acceptsBody(value) {
+		p.parent.warnOnce("default/"+p.path(), fmt.Sprintf("default value of property '%s' does not fit its attribute and is ignored", p.path()))
+		return nil
+	}
+	return value
+}
+
+// acceptsBody returns true if the given representation of the property in a body can be converted into a value of its attribute.
+// Values of polymorphic properties are not accepted, since their variant is only chosen when converting them.
+func (p *augmentedPropertySchema) acceptsBody(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch p.GetTopSchemaType() {
+	case propertyTypeBool:
+		_, ok := value.(bool)
+		return ok
+	case propertyTypeInt:
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := number.Int64()
+		return err == nil
+	case propertyTypeFloat:
+		_, ok := value.(json.Number)
+		return ok
+	case propertyTypeString:
+		_, ok := value.(string)
+		return ok
+	case propertyTypeObject:
+		fields, ok := value.(map[string]any)
+		if !ok || p.isUnion() {
+			return false
+		}
+		for _, nested := range p.properties {
+			if !nested.acceptsBody(fields[nested.Name]) {
+				return false
+			}
+		}
+		return true
+	case propertyTypeList, propertyTypeSet:
+		items, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if !p.items.acceptsBody(item) {
+				return false
+			}
+		}
+		return true
+	case propertyTypeMap:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, field := range fields {
+			if !p.items.acceptsBody(field) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// normalizeBodyNumbers replaces the numbers in a decoded value with json.Number, as found in response bodies.
+func normalizeBodyNumbers(value any) any {
+	switch value := value.(type) {
+	case int:
+		return json.Number(strconv.Itoa(value))
+	case int64:
+		return json.Number(strconv.FormatInt(value, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(value, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+	case []any:
+		for i, item := range value {
+			value[i] = normalizeBodyNumbers(item)
+		}
+	case map[string]any:
+		for key, field := range value {
+			value[key] = normalizeBodyNumbers(field)
+		}
+	}
+	return value
+}
+
+// renderBodyLiteral generates a Go expression for a value in its representation in a body, as returned by defaultValue.
+func renderBodyLiteral(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "nil"
+	case json.Number:
+		return fmt.Sprintf("json.Number(%q)", value.String())
+	case []any:
+		var items []string
+		for _, item := range value {
+			items = append(items, renderBodyLiteral(item))
+		}
+		return fmt.Sprintf("[]any{%s}", strings.Join(items, ", "))
+	case map[string]any:
+		var fields []string
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			fields = append(fields, fmt.Sprintf("%q: %s", key, renderBodyLiteral(value[key])))
+		}
+		return fmt.Sprintf("map[string]any{%s}", strings.Join(fields, ", "))
+	default:
+		return fmt.Sprintf("%#v", value)
+	}
+}
+
+// renderDefault generates the Default field of the attribute definition, or an empty string if the attribute has no default.
+// Defaults of primitive types are static values; other defaults are converted from their representation in a body like responses are.
+func (p *augmentedPropertySchema) renderDefault() string {
+	if !p.hasDefault() || !p.IsComputed() {
+		return ""
+	}
+	value := p.defaultValue()
+	switch p.GetTopSchemaType() {
+	case propertyTypeBool:
+		return fmt.Sprintf("Default: booldefault.StaticBool(%t)", value)
+	case propertyTypeInt:
+		number, _ := value.(json.Number).Int64()
+		return fmt.Sprintf("Default: int64default.StaticInt64(%d)", number)
+	case propertyTypeFloat:
+		return fmt.Sprintf("Default: float64default.StaticFloat64(%s)", value.(json.Number).String())
+	case propertyTypeString:
+		return fmt.Sprintf("Default: stringdefault.StaticString(%q)", value)
+	default:
+		return fmt.Sprintf("Default: %sdefault.StaticValue(mustDefault(%s))", strings.ToLower(p.GetTypeType()), p.renderFromBody(renderBodyLiteral(value)))
+	}
+}
+
+// renderFlags generates the Required, Optional, Computed and Sensitive flags of the attribute definition.
+func (p *augmentedPropertySchema) renderFlags() string {
+	var flags []string
+	if p.IsRequired() {
+		flags = append(flags, "Required: true,")
+	}
+	if p.IsOptional() {
+		flags = append(flags, "Optional: true,")
+	}
+	if p.IsComputed() {
+		flags = append(flags, "Computed: true,")
+	}
+	if p.IsSensitive() {
+		flags = append(flags, "Sensitive: true,")
+	}
+	return strings.Join(flags, " ")
+}
+
 // IsNullable returns true if the property can be null.
 func (p *augmentedPropertySchema) IsNullable() bool {
 	hasNullableType := slices.Contains(p.Schema.Type, "null")
 	return (p.Schema.Nullable != nil && *p.Schema.Nullable) || hasNullableType
 }
 
+// IsSensitive returns true if the value of the property must not be disclosed.
+// This is indicated by the password format, writeOnly, the x-sensitive extension, or the sensitive paths of the provider spec.
+// Collections are sensitive if their items are.
+func (p *augmentedPropertySchema) IsSensitive() bool {
+	if p.Schema.Format == "password" || (p.Schema.WriteOnly != nil && *p.Schema.WriteOnly) || isExtensionTrue(p.Schema, "x-sensitive") {
+		return true
+	}
+	if p.items != nil && p.items.IsSensitive() {
+		return true
+	}
+	return !p.isItem && slices.Contains(p.parent.ResourceInfo.ResourceSpec().Sensitive, p.path())
+}
+
+// bodyKey returns the key of the property in the object containing it in request and response bodies.
+// Items and variants are not held by a key of their own, so they share the key of their owner.
+func (p *augmentedPropertySchema) bodyKey() string {
+	if p.isItem || p.isVariant {
+		return p.owner.bodyKey()
+	}
+	return p.Name
+}
+
+// sensitiveKeys returns the body keys of the property and its nested properties whose values must not be disclosed in logs.
+// The nested properties of a sensitive property are covered by its own key.
+func (p *augmentedPropertySchema) sensitiveKeys() []string {
+	if p.IsSensitive() {
+		return []string{p.bodyKey()}
+	}
+	var keys []string
+	if p.items != nil {
+		keys = append(keys, p.items.sensitiveKeys()...)
+	}
+	for _, nested := range p.properties {
+		keys = append(keys, nested.sensitiveKeys()...)
+	}
+	return keys
+}
+
+// isExtensionTrue returns true if the schema has the given extension with the value true.
+func isExtensionTrue(schema *base.Schema, name string) bool {
+	if schema.Extensions == nil {
+		return false
+	}
+	node, present := schema.Extensions.Get(name)
+	if !present || node == nil {
+		return false
+	}
+	var value bool
+	return node.Decode(&value) == nil && value
+}
+
 // GetTypeType returns the Terraform types package type name for this property (e.g., "String", "Int64").
 func (p *augmentedPropertySchema) GetTypeType() string {
 	switch p.GetTopSchemaType() {
@@ -95,8 +797,18 @@ func (p *augmentedPropertySchema) GetTypeType() string {
 		return "Float64"
 	case propertyTypeString:
 		return "String"
+	case propertyTypeObject:
+		return "Object"
+	case propertyTypeList:
+		return "List"
+	case propertyTypeSet:
+		return "Set"
+	case propertyTypeMap:
+		return "Map"
 	case propertyTypeAny:
 		return "Dynamic"
+	case propertyTypeJSON:
+		return "String"
 	default:
 		logger.Warn(fmt.Sprintf("Invalid property type enum found: %s  . Defaulting to 'any' type.", p.GetTopSchemaType()))
 		return "Dynamic"
@@ -114,8 +826,27 @@ func (p *augmentedPropertySchema) GetSchemaType() string {
 		return "Float64Attribute"
 	case propertyTypeString:
 		return "StringAttribute"
+	case propertyTypeObject:
+		return "SingleNestedAttribute"
+	case propertyTypeList:
+		if p.items.GetTopSchemaType() == propertyTypeObject {
+			return "ListNestedAttribute"
+		}
+		return "ListAttribute"
+	case propertyTypeSet:
+		if p.items.GetTopSchemaType() == propertyTypeObject {
+			return "SetNestedAttribute"
+		}
+		return "SetAttribute"
+	case propertyTypeMap:
+		if p.items.GetTopSchemaType() == propertyTypeObject {
+			return "MapNestedAttribute"
+		}
+		return "MapAttribute"
 	case propertyTypeAny:
 		return "DynamicAttribute"
+	case propertyTypeJSON:
+		return "StringAttribute"
 	default:
 		logger.Warn(fmt.Sprintf("Invalid property type enum found: %s  . Defaulting to 'any' type.", p.GetTopSchemaType()))
 		return "DynamicAttribute"
@@ -133,8 +864,18 @@ func (p *augmentedPropertySchema) GetValidatorType() string {
 		return "Float64"
 	case propertyTypeString:
 		return "String"
+	case propertyTypeObject:
+		return "Object"
+	case propertyTypeList:
+		return "List"
+	case propertyTypeSet:
+		return "Set"
+	case propertyTypeMap:
+		return "Map"
 	case propertyTypeAny:
 		return "Dynamic"
+	case propertyTypeJSON:
+		return "String"
 	default:
 		logger.Warn(fmt.Sprintf("Invalid property type enum found: %s  . Defaulting to 'any' type.", p.GetTopSchemaType()))
 		return "Dynamic"
@@ -152,8 +893,16 @@ func (p *augmentedPropertySchema) GetGoType() string {
 		return "float64"
 	case propertyTypeString:
 		return "string"
+	case propertyTypeObject:
+		return "map[string]any"
+	case propertyTypeList, propertyTypeSet:
+		return "[]any"
+	case propertyTypeMap:
+		return "map[string]any"
 	case propertyTypeAny:
 		return "any"
+	case propertyTypeJSON:
+		return "string"
 	default:
 		logger.Warn(fmt.Sprintf("Invalid property type enum found: %s  . Defaulting to 'any' type.", p.GetTopSchemaType()))
 		return "any"
@@ -162,122 +911,761 @@ func (p *augmentedPropertySchema) GetGoType() string {
 
 // RenderModelDataFields generates a Go struct field declaration for this property in the Terraform resource model.
 func (p *augmentedPropertySchema) RenderModelDataFields() string {
-	return fmt.Sprintf("%s types.%s `tfsdk:\"%s\"`", casing.Camel(p.Name), p.GetTypeType(), casing.Snake(p.Name))
+	return fmt.Sprintf("%s types.%s `tfsdk:\"%s\"`", casing.Camel(p.Name), p.GetTypeType(), p.AttributeName())
 }
 
 // RenderAttributeDefinitions generates Terraform schema attribute definition code for this property.
 func (p *augmentedPropertySchema) RenderAttributeDefinitions() string {
-	schemaDef := fmt.Sprintf(
-		"schema.%s { Validators: []validator.%s { &OpenApiSchemaValidator{ operationPath: \"%s\", operationMethod: \"%s\", propertyName: \"%s\" } } },",
-		p.GetSchemaType(),
-		p.GetValidatorType(),
-		p.parent.ResourceInfo.ResourceSpec().GetOperationPath(provider_spec.Create, p.parent.ProviderInfo.SpecDefaults),
-		p.parent.ResourceInfo.ResourceSpec().GetOperationMethod(provider_spec.Create, p.parent.ProviderInfo.SpecDefaults),
-		p.Name,
-	)
-	return fmt.Sprintf(`"%s": %s`, p.Name, schemaDef)
+	return fmt.Sprintf(`"%s": %s`, p.AttributeName(), p.renderAttributeDefinition())
 }
 
-// RenderFillCreateBody generates code to populate this property in the API request body during resource creation.
-func (p *augmentedPropertySchema) RenderFillCreateBody() string {
+// renderAttributeDefinition generates the Terraform schema attribute of this property.
+func (p *augmentedPropertySchema) renderAttributeDefinition() string {
+	var fields []string
 	switch p.GetTopSchemaType() {
-	case propertyTypeAny:
-		fmtStr := `%[2]sUnpacked, err := UnpackDynamicType(&data.%[3]s, ctx)
-if err != nil {
-  resp.Diagnostics.AddError("cannot unpack data", fmt.Sprintf("cannot unpack data of property '%[1]s' due to error: %%v", err))
+	case propertyTypeObject:
+		fields = append(fields, p.renderNestedAttributes())
+	case propertyTypeList, propertyTypeSet, propertyTypeMap:
+		if p.items.GetTopSchemaType() == propertyTypeObject {
+			fields = append(fields, fmt.Sprintf("NestedObject: schema.NestedAttributeObject{ %s }", p.items.renderNestedAttributes()))
+		} else {
+			fields = append(fields, fmt.Sprintf("ElementType: %s", p.items.renderAttributeType()))
+		}
+	}
+	if validators := p.renderValidators(); validators != "" {
+		fields = append(fields, validators)
+	}
+	if defaultValue := p.renderDefault(); defaultValue != "" {
+		fields = append(fields, defaultValue)
+	}
+	fields = append(fields, p.renderFlags())
+	return fmt.Sprintf("schema.%s { %s },", p.GetSchemaType(), strings.Join(fields, ", "))
 }
-requestBody["%[1]s"] = %[2]sUnpacked`
-		return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name))
-	default:
-		fmtStr := `requestBody["%s"] = data.%s.Value%s()`
-		return fmt.Sprintf(fmtStr, p.Name, casing.Camel(p.Name), p.GetTypeType())
+
+// renderValidators generates the Validators field of the attribute definition, or an empty string if the attribute has no validators.
+func (p *augmentedPropertySchema) renderValidators() string {
+	validators := p.renderConstraintValidators()
+	validators = append(validators, p.renderVariantValidators()...)
+	validators = append(validators, p.renderMapKeyValidators()...)
+	if len(validators) == 0 {
+		return ""
 	}
+	return fmt.Sprintf("Validators: []validator.%s{ %s }", p.GetValidatorType(), strings.Join(validators, ", "))
 }
 
-// RenderUpdateDataWithCreateResponse generates code to update Terraform state with this property's value from the API response.
-func (p *augmentedPropertySchema) RenderUpdateDataWithCreateResponse() string {
-	var fmtStr string
+// renderConstraintValidators generates the validators checking the constraints declared for the values of the property.
+// Constraints of the items of collections are checked by element validators; nested attributes of objects have validators of their own.
+// Only attributes that can be set in the configuration are validated.
+func (p *augmentedPropertySchema) renderConstraintValidators() []string {
+	if !p.IsRequired() && !p.IsOptional() {
+		return nil
+	}
+	validators := p.renderEnumValidators()
 	switch p.GetTopSchemaType() {
-	case propertyTypeAny:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, err := anyToDynamic(%[2]sRaw); err == nil {
-		data.%[3]s = %[2]sValue
-	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", err.Error())
+	case propertyTypeString:
+		if p.Schema.Pattern != "" {
+			if _, err := regexp.Compile(p.Schema.Pattern); err != nil {
+				p.parent.warnOnce("pattern/"+p.path(), fmt.Sprintf("pattern of property '%s' is not a valid Go regular expression and will not be validated: %v", p.path(), err))
+			} else {
+				validators = append(validators, fmt.Sprintf(
+					"stringvalidator.RegexMatches(regexp.MustCompile(%q), %q)",
+					p.Schema.Pattern,
+					fmt.Sprintf("must match the pattern %s", p.Schema.Pattern),
+				))
+			}
+		}
+		validators = append(validators, renderSizeValidators("stringvalidator.UTF8Length", p.Schema.MinLength, p.Schema.MaxLength)...)
+	case propertyTypeInt:
+		lower := getNumberBound(p.Schema.Minimum, p.Schema.ExclusiveMinimum, false)
+		upper := getNumberBound(p.Schema.Maximum, p.Schema.ExclusiveMaximum, true)
+		validators = append(validators, renderSizeValidators("int64validator.", lower.int64Bound(false), upper.int64Bound(true))...)
+		validators = append(validators, p.renderMultipleOfValidators()...)
+	case propertyTypeFloat:
+		lower := getNumberBound(p.Schema.Minimum, p.Schema.ExclusiveMinimum, false)
+		upper := getNumberBound(p.Schema.Maximum, p.Schema.ExclusiveMaximum, true)
+		if lower != nil && upper != nil && !lower.exclusive && !upper.exclusive {
+			validators = append(validators, fmt.Sprintf("float64validator.Between(%s, %s)", renderFloat(lower.value), renderFloat(upper.value)))
+		} else {
+			validators = append(validators, lower.renderFloat64Validators(false)...)
+			validators = append(validators, upper.renderFloat64Validators(true)...)
+		}
+		validators = append(validators, p.renderMultipleOfValidators()...)
+	case propertyTypeList, propertyTypeSet:
+		validators = append(validators, renderSizeValidators(strings.ToLower(p.GetValidatorType())+"validator.Size", p.Schema.MinItems, p.Schema.MaxItems)...)
+		validators = append(validators, p.renderElementValidators()...)
+	case propertyTypeMap:
+		validators = append(validators, p.renderElementValidators()...)
 	}
-}`
+	return validators
+}
+
+// renderEnumValidators generates the validator checking that a string or number property has one of the values of its enum.
+// Null is allowed for every attribute, so it is skipped; values that do not fit the attribute are reported and disable the validator.
+func (p *augmentedPropertySchema) renderEnumValidators() []string {
+	schemaType := p.GetTopSchemaType()
+	if len(p.Schema.Enum) == 0 || (schemaType != propertyTypeString && schemaType != propertyTypeInt && schemaType != propertyTypeFloat) {
+		return nil
+	}
+	var values []string
+	for _, node := range p.Schema.Enum {
+		var value any
+		if err := node.Decode(&value); err != nil {
+			p.parent.warnOnce("enum/"+p.path(), fmt.Sprintf("enum of property '%s' cannot be decoded and will not be validated: %v", p.path(), err))
+			return nil
+		}
+		value = normalizeBodyNumbers(value)
+		if value == nil {
+			continue
+		}
+		if !p.acceptsBody(value) {
+			p.parent.warnOnce("enum/"+p.path(), fmt.Sprintf("enum of property '%s' has values that do not fit its attribute and will not be validated", p.path()))
+			return nil
+		}
+		switch value := value.(type) {
+		case string:
+			values = append(values, fmt.Sprintf("%q", value))
+		case json.Number:
+			values = append(values, value.String())
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%svalidator.OneOf(%s)", strings.ToLower(p.GetValidatorType()), strings.Join(values, ", "))}
+}
+
+// renderMultipleOfValidators generates the validator checking that a number property is a multiple of its multipleOf.
+func (p *augmentedPropertySchema) renderMultipleOfValidators() []string {
+	if p.Schema.MultipleOf == nil || *p.Schema.MultipleOf <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("multipleOfValidator{divisor: %s}", renderFloat(*p.Schema.MultipleOf))}
+}
+
+// renderElementValidators generates the validator applying the constraint validators of the items of a collection to each element.
+// Elements that are objects are validated by the validators of their nested attributes instead.
+func (p *augmentedPropertySchema) renderElementValidators() []string {
+	switch p.items.GetTopSchemaType() {
+	case propertyTypeString, propertyTypeInt, propertyTypeFloat, propertyTypeList, propertyTypeSet, propertyTypeMap:
 	default:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, ok := %[2]sRaw.(%[5]s); ok {
-		data.%[3]s = types.%[4]sValue(%[2]sValue)
-	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", "'%[1]s' field is not of the expected type")
+		return nil
 	}
-}`
+	elementValidators := p.items.renderConstraintValidators()
+	if len(elementValidators) == 0 {
+		return nil
 	}
-	return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name), p.GetTypeType(), p.GetGoType())
+	return []string{fmt.Sprintf(
+		"%svalidator.Value%ssAre(%s)",
+		strings.ToLower(p.GetValidatorType()),
+		p.items.GetValidatorType(),
+		strings.Join(elementValidators, ", "),
+	)}
 }
 
-// RenderFillUpdateBody generates code to populate this property in the API request body during resource creation.
-func (p *augmentedPropertySchema) RenderFillUpdateBody() string {
+// renderVariantValidators generates the validator ensuring that exactly one variant of a polymorphic property is set.
+// It is attached to the first variant that can be set in the configuration and refers to the others that can be set.
+// Variants that cannot be set in the configuration are not validated.
+func (p *augmentedPropertySchema) renderVariantValidators() []string {
+	if !p.isVariant || p.parent.IsDataSource || !p.isInRequest() {
+		return nil
+	}
+	first := true
+	var others []string
+	for i := range p.owner.properties {
+		variant := &p.owner.properties[i]
+		if !variant.isInRequest() {
+			continue
+		}
+		if variant.Name == p.Name {
+			first = len(others) == 0
+			continue
+		}
+		others = append(others, fmt.Sprintf("%q", variant.AttributeName()))
+	}
+	if !first {
+		return nil
+	}
+	return []string{fmt.Sprintf("exactlyOneVariant(%s)", strings.Join(others, ", "))}
+}
+
+// renderMapKeyValidators generates the validator of a map property that checks its keys against their pattern.
+// Patterns that are not supported by Go regular expressions are skipped.
+func (p *augmentedPropertySchema) renderMapKeyValidators() []string {
+	if p.GetTopSchemaType() != propertyTypeMap {
+		return nil
+	}
+	pattern := p.mapKeyPattern()
+	if pattern == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		p.parent.warnOnce(p.path(), fmt.Sprintf("pattern of the keys of property '%s' is not a valid Go regular expression; keys will not be validated: %v", p.path(), err))
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(%q), %q))",
+		pattern,
+		fmt.Sprintf("must match the pattern %s", pattern),
+	)}
+}
+
+// renderSizeValidators generates the validators for an optional lower and upper bound, given the common prefix of the names of the validator functions.
+// Both bounds are checked by a single Between validator if both are declared.
+func renderSizeValidators[T int64 | int](prefix string, minimum *T, maximum *T) []string {
+	switch {
+	case minimum != nil && maximum != nil:
+		return []string{fmt.Sprintf("%sBetween(%d, %d)", prefix, *minimum, *maximum)}
+	case minimum != nil:
+		return []string{fmt.Sprintf("%sAtLeast(%d)", prefix, *minimum)}
+	case maximum != nil:
+		return []string{fmt.Sprintf("%sAtMost(%d)", prefix, *maximum)}
+	default:
+		return nil
+	}
+}
+
+// numberBound is a lower or upper bound of the values of a number property.
+type numberBound struct {
+	value     float64
+	exclusive bool
+}
+
+// getNumberBound returns the bound declared by minimum and exclusiveMinimum, or by maximum and exclusiveMaximum, or nil if there is none.
+// Exclusive bounds are flags of the inclusive bound in OpenAPI 3.0 and numbers of their own in 3.1; the stricter bound wins if both are declared.
+func getNumberBound(inclusive *float64, exclusive *base.DynamicValue[bool, float64], upper bool) *numberBound {
+	var bound *numberBound
+	if inclusive != nil {
+		bound = &numberBound{value: *inclusive, exclusive: exclusive != nil && exclusive.IsA() && exclusive.A}
+	}
+	if exclusive != nil && exclusive.IsB() {
+		if bound == nil || (upper && exclusive.B <= bound.value) || (!upper && exclusive.B >= bound.value) {
+			bound = &numberBound{value: exclusive.B, exclusive: true}
+		}
+	}
+	return bound
+}
+
+// int64Bound returns the inclusive bound for integers equivalent to this bound, or nil if there is no bound or it does not restrict int64 values.
+func (b *numberBound) int64Bound(upper bool) *int64 {
+	if b == nil {
+		return nil
+	}
+	var value float64
+	switch {
+	case upper && b.exclusive:
+		value = math.Ceil(b.value) - 1
+	case upper:
+		value = math.Floor(b.value)
+	case b.exclusive:
+		value = math.Floor(b.value) + 1
+	default:
+		value = math.Ceil(b.value)
+	}
+	if value < math.MinInt64 || value >= math.MaxInt64 {
+		return nil
+	}
+	result := int64(value)
+	return &result
+}
+
+// renderFloat64Validators generates the validator checking this bound of a float property, or nothing if there is no bound.
+func (b *numberBound) renderFloat64Validators(upper bool) []string {
+	switch {
+	case b == nil:
+		return nil
+	case b.exclusive:
+		return []string{fmt.Sprintf("exclusiveBoundValidator{bound: %s, upper: %t}", renderFloat(b.value), upper)}
+	case upper:
+		return []string{fmt.Sprintf("float64validator.AtMost(%s)", renderFloat(b.value))}
+	default:
+		return []string{fmt.Sprintf("float64validator.AtLeast(%s)", renderFloat(b.value))}
+	}
+}
+
+// renderFloat generates the shortest Go literal for a float value.
+func renderFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// RenderBodyPathEntry generates the entry of the property in a map from body keys to the attributes holding their values.
+func (p *augmentedPropertySchema) RenderBodyPathEntry() string {
+	if paths := p.renderBodyPaths(); paths != "nil" {
+		return fmt.Sprintf("%q: {name: %q, paths: %s},", p.Name, p.AttributeName(), paths)
+	}
+	return fmt.Sprintf("%q: {name: %q},", p.Name, p.AttributeName())
+}
+
+// renderBodyPaths generates a *bodyPaths expression mapping locations in the value of the property to the paths of its nested attributes.
+// Sets, polymorphic objects and values encoded as JSON or of any type have no nested attributes that locations can be mapped to.
+func (p *augmentedPropertySchema) renderBodyPaths() string {
 	switch p.GetTopSchemaType() {
-	case propertyTypeAny:
-		fmtStr := `%[2]sUnpacked, err := UnpackDynamicType(&data.%[3]s, ctx)
-if err != nil {
-  resp.Diagnostics.AddError("cannot unpack data", fmt.Sprintf("cannot unpack data of property '%[1]s' due to error: %%v", err))
+	case propertyTypeObject:
+		if p.isUnion() {
+			return "nil"
+		}
+		entries := strings.Builder{}
+		for _, nested := range p.properties {
+			entries.WriteString(nested.RenderBodyPathEntry())
+			entries.WriteRune('\n')
+		}
+		return fmt.Sprintf("&bodyPaths{attributes: map[string]bodyAttribute{\n%s}}", entries.String())
+	case propertyTypeList:
+		return fmt.Sprintf("&bodyPaths{listElements: %s}", p.items.renderElementBodyPaths())
+	case propertyTypeMap:
+		return fmt.Sprintf("&bodyPaths{mapValues: %s}", p.items.renderElementBodyPaths())
+	default:
+		return "nil"
+	}
 }
-requestBody["%[1]s"] = %[2]sUnpacked`
-		return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name))
+
+// renderElementBodyPaths generates the *bodyPaths expression for the items of a list or map, which is never nil so that indexes and keys are mapped.
+func (p *augmentedPropertySchema) renderElementBodyPaths() string {
+	if paths := p.renderBodyPaths(); paths != "nil" {
+		return paths
+	}
+	return "&bodyPaths{}"
+}
+
+// renderNestedAttributes generates the attribute definitions of the nested properties of an object property.
+func (p *augmentedPropertySchema) renderNestedAttributes() string {
+	attributes := strings.Builder{}
+	for _, nested := range p.properties {
+		attributes.WriteString(nested.RenderAttributeDefinitions())
+		attributes.WriteRune('\n')
+	}
+	return fmt.Sprintf("Attributes: map[string]schema.Attribute{\n%s}", attributes.String())
+}
+
+// renderAttributeType generates an expression for the attr.Type of this property.
+func (p *augmentedPropertySchema) renderAttributeType() string {
+	switch p.GetTopSchemaType() {
+	case propertyTypeObject:
+		return fmt.Sprintf("types.ObjectType{AttrTypes: %s{}.attributeTypes()}", p.ModelTypeName())
+	case propertyTypeList, propertyTypeSet, propertyTypeMap:
+		return fmt.Sprintf("types.%sType{ElemType: %s}", p.GetTypeType(), p.items.renderAttributeType())
 	default:
-		fmtStr := `requestBody["%s"] = data.%s.Value%s()`
-		return fmt.Sprintf(fmtStr, p.Name, casing.Camel(p.Name), p.GetTypeType())
+		return fmt.Sprintf("types.%sType", p.GetTypeType())
 	}
 }
 
-// RenderUpdateDataWithUpdateResponse generates code to update Terraform state with this property's value from the API response.
-func (p *augmentedPropertySchema) RenderUpdateDataWithUpdateResponse() string {
-	var fmtStr string
+// renderNullValue generates an expression for the null value of this property.
+func (p *augmentedPropertySchema) renderNullValue() string {
 	switch p.GetTopSchemaType() {
-	case propertyTypeAny:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, err := anyToDynamic(%[2]sRaw); err == nil {
-		data.%[3]s = %[2]sValue
-	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", err.Error())
+	case propertyTypeObject:
+		return fmt.Sprintf("types.ObjectNull(%s{}.attributeTypes())", p.ModelTypeName())
+	case propertyTypeList, propertyTypeSet, propertyTypeMap:
+		return fmt.Sprintf("types.%sNull(%s)", p.GetTypeType(), p.items.renderAttributeType())
+	default:
+		return fmt.Sprintf("types.%sNull()", p.GetTypeType())
 	}
-}`
+}
+
+// renderToBody generates an expression converting the given value of this property into its representation in a request body.
+// Values without objects are converted generically; objects need the generated conversion functions to map attribute names to body keys.
+func (p *augmentedPropertySchema) renderToBody(value string) string {
+	switch {
+	case p.GetTopSchemaType() == propertyTypeObject:
+		return fmt.Sprintf("%s(ctx, %s)", p.conversionFuncName("ToBody"), value)
+	case p.GetTopSchemaType() == propertyTypeJSON:
+		return fmt.Sprintf("jsonStringToBody(%s)", value)
+	case p.GetTopSchemaType() == propertyTypeMap && p.containsObject():
+		return fmt.Sprintf("mapToBody(ctx, %s, %s)", value, p.items.renderToBodyFunc())
+	case p.isCollection() && p.containsObject():
+		return fmt.Sprintf("collectionToBody(ctx, %s, %s)", value, p.items.renderToBodyFunc())
 	default:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, ok := %[2]sRaw.(%[5]s); ok {
-		data.%[3]s = types.%[4]sValue(%[2]sValue)
-	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", "'%[1]s' field is not of the expected type")
+		return fmt.Sprintf("valueToBody(ctx, %s)", value)
 	}
-}`
+}
+
+// renderToBodyFunc generates a function converting values of this property into their representation in a request body.
+func (p *augmentedPropertySchema) renderToBodyFunc() string {
+	if p.GetTopSchemaType() == propertyTypeObject {
+		return p.conversionFuncName("ToBody")
 	}
-	return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name), p.GetTypeType(), p.GetGoType())
+	return fmt.Sprintf("func(ctx context.Context, value types.%s) (any, error) { return %s }", p.GetTypeType(), p.renderToBody("value"))
 }
 
-// RenderUpdateDataWithReadResponse generates code to update Terraform state with this property's value from the API response.
-func (p *augmentedPropertySchema) RenderUpdateDataWithReadResponse() string {
-	var fmtStr string
+// renderFromBody generates an expression converting the given representation of this property in a response body into a value.
+func (p *augmentedPropertySchema) renderFromBody(body string) string {
+	switch {
+	case p.GetTopSchemaType() == propertyTypeObject:
+		return fmt.Sprintf("%s(ctx, %s)", p.conversionFuncName("FromBody"), body)
+	case p.GetTopSchemaType() == propertyTypeJSON:
+		return fmt.Sprintf("bodyToJSONString(%s)", body)
+	case p.isCollection() && p.containsObject():
+		return fmt.Sprintf("bodyTo%s(ctx, %s, %s, %s)", p.GetTypeType(), p.items.renderAttributeType(), body, p.items.renderFromBodyFunc())
+	default:
+		return fmt.Sprintf("bodyToValue[types.%s](ctx, %s, %s)", p.GetTypeType(), p.renderAttributeType(), body)
+	}
+}
+
+// renderFromBodyFunc generates a function converting representations of this property in a response body into values.
+func (p *augmentedPropertySchema) renderFromBodyFunc() string {
+	if p.GetTopSchemaType() == propertyTypeObject {
+		return p.conversionFuncName("FromBody")
+	}
+	return fmt.Sprintf("func(ctx context.Context, body any) (types.%s, error) { return %s }", p.GetTypeType(), p.renderFromBody("body"))
+}
+
+// RenderNestedModels generates the model structs and conversion methods of this property and all objects nested in it.
+func (p *augmentedPropertySchema) RenderNestedModels() string {
+	if p.isCollection() {
+		return p.items.RenderNestedModels()
+	}
+	if p.GetTopSchemaType() != propertyTypeObject {
+		return ""
+	}
+	if p.isUnion() {
+		return p.renderUnionModels()
+	}
+	fields := strings.Builder{}
+	attributeTypes := strings.Builder{}
+	toBody := strings.Builder{}
+	fromBody := strings.Builder{}
+	nestedModels := strings.Builder{}
+	for _, nested := range p.properties {
+		fields.WriteString(nested.RenderModelDataFields())
+		fields.WriteRune('\n')
+		attributeTypes.WriteString(fmt.Sprintf("%q: %s,\n", nested.AttributeName(), nested.renderAttributeType()))
+		if !nested.isReadOnly() {
+			toBody.WriteString(fmt.Sprintf(`if %[2]sBody, err := %[3]s; err != nil {
+	return nil, fmt.Errorf("%[1]s: %%w", err)
+} else if %[2]sBody != nil {
+	body[%[1]q] = %[2]sBody
+}
+`, nested.Name, casing.LowerCamel(nested.Name), nested.renderToBody("model."+casing.Camel(nested.Name))))
+		}
+		fromBody.WriteString(fmt.Sprintf(`if model.%[2]s, err = %[3]s; err != nil {
+	return types.ObjectNull(model.attributeTypes()), fmt.Errorf("%[1]s: %%w", err)
+}
+`, nested.Name, casing.Camel(nested.Name), nested.renderFromBody(fmt.Sprintf("fields[%q]", nested.Name))))
+		nestedModels.WriteString(nested.RenderNestedModels())
+	}
+
+	fmtStr := `// %[1]s describes the data model of the nested attribute '%[2]s'.
+type %[1]s struct {
+	%[3]s
+}
+
+// attributeTypes returns the types of the attributes of %[1]s.
+func (%[1]s) attributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		%[4]s
+	}
+}
+
+// %[8]s converts a value of the nested attribute into its representation in a request body.
+func %[8]s(ctx context.Context, value types.Object) (any, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var model %[1]s
+	if diags := value.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	body := make(map[string]any)
+	%[5]s
+	return body, nil
+}
+
+// %[9]s converts the representation of the nested attribute in a response body into a value.
+func %[9]s(ctx context.Context, body any) (types.Object, error) {
+	var model %[1]s
+	if body == nil {
+		return types.ObjectNull(model.attributeTypes()), nil
+	}
+	fields, err := bodyToObjectFields(body)
+	if err != nil {
+		return types.ObjectNull(model.attributeTypes()), err
+	}
+	%[6]s
+	value, diags := types.ObjectValueFrom(ctx, model.attributeTypes(), model)
+	if diags.HasError() {
+		return value, diagnosticsError(diags)
+	}
+	return value, nil
+}
+
+%[7]s`
+	return fmt.Sprintf(fmtStr, p.ModelTypeName(), p.path(), fields.String(), attributeTypes.String(), toBody.String(), fromBody.String(), nestedModels.String(), p.conversionFuncName("ToBody"), p.conversionFuncName("FromBody")) +
+		p.renderNullUnknownModel()
+}
+
+// renderUnionModels generates the model struct and conversion methods of a polymorphic property and all its variants.
+// Requests contain the value of the chosen variant, together with its discriminator value.
+// Responses are mapped to the variant selected by the discriminator, or to the first variant matching their structure.
+func (p *augmentedPropertySchema) renderUnionModels() string {
+	fields := strings.Builder{}
+	attributeTypes := strings.Builder{}
+	nullVariants := strings.Builder{}
+	toBody := strings.Builder{}
+	fromBody := strings.Builder{}
+	shapes := strings.Builder{}
+	nestedModels := strings.Builder{}
+	discriminator := p.discriminatorProperty()
+	for i, variant := range p.properties {
+		fieldName := casing.Camel(variant.Name)
+		fields.WriteString(variant.RenderModelDataFields())
+		fields.WriteRune('\n')
+		attributeTypes.WriteString(fmt.Sprintf("%q: %s,\n", variant.AttributeName(), variant.renderAttributeType()))
+		nullVariants.WriteString(fmt.Sprintf("model.%s = %s\n", fieldName, variant.renderNullValue()))
+		result := "body"
+		if discriminator != "" {
+			result = fmt.Sprintf("withDiscriminator(body, %q, %q)", discriminator, variant.discriminatorValue)
+		}
+		toBody.WriteString(fmt.Sprintf(`if !model.%[2]s.IsNull() && !model.%[2]s.IsUnknown() {
+	body, err := %[3]s
+	if err != nil {
+		return nil, fmt.Errorf("%[1]s: %%w", err)
+	}
+	return %[4]s, nil
+}
+`, variant.Name, fieldName, variant.renderToBody("model."+fieldName), result))
+		selector := fmt.Sprintf("%d", i)
+		if discriminator != "" {
+			selector = fmt.Sprintf("%q", variant.discriminatorValue)
+		}
+		fromBody.WriteString(fmt.Sprintf(`case %[3]s:
+	if model.%[2]s, err = %[4]s; err != nil {
+		return types.ObjectNull(model.attributeTypes()), fmt.Errorf("%[1]s: %%w", err)
+	}
+`, variant.Name, fieldName, selector, variant.renderFromBody("fields")))
+		var properties []string
+		var required []string
+		for name := range variant.Schema.Properties.FromOldest() {
+			properties = append(properties, fmt.Sprintf("%q", name))
+		}
+		for _, name := range variant.Schema.Required {
+			required = append(required, fmt.Sprintf("%q", name))
+		}
+		shapes.WriteString(fmt.Sprintf("variantShape{properties: []string{%s}, required: []string{%s}},\n", strings.Join(properties, ", "), strings.Join(required, ", ")))
+		nestedModels.WriteString(variant.RenderNestedModels())
+	}
+	selection := fmt.Sprintf("matchVariant(fields,\n%s)", shapes.String())
+	mismatch := `fmt.Errorf("value does not match any variant")`
+	if discriminator != "" {
+		selection = fmt.Sprintf("fields[%q]", discriminator)
+		mismatch = fmt.Sprintf(`fmt.Errorf("unknown value %%v of discriminator '%s'", fields[%q])`, discriminator, discriminator)
+	}
+
+	fmtStr := `// %[1]s describes the data model of the polymorphic attribute '%[2]s', with one attribute per variant.
+type %[1]s struct {
+	%[3]s
+}
+
+// attributeTypes returns the types of the attributes of %[1]s.
+func (%[1]s) attributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		%[4]s
+	}
+}
+
+// %[10]s converts a value of the polymorphic attribute into the representation of its chosen variant in a request body.
+func %[10]s(ctx context.Context, value types.Object) (any, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var model %[1]s
+	if diags := value.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	%[5]s
+	return nil, nil
+}
+
+// %[11]s converts the representation of the polymorphic attribute in a response body into a value with the matching variant set.
+func %[11]s(ctx context.Context, body any) (types.Object, error) {
+	var model %[1]s
+	if body == nil {
+		return types.ObjectNull(model.attributeTypes()), nil
+	}
+	fields, err := bodyToObjectFields(body)
+	if err != nil {
+		return types.ObjectNull(model.attributeTypes()), err
+	}
+	%[6]s
+	switch %[7]s {
+	%[8]s
+	default:
+		return types.ObjectNull(model.attributeTypes()), %[12]s
+	}
+	value, diags := types.ObjectValueFrom(ctx, model.attributeTypes(), model)
+	if diags.HasError() {
+		return value, diagnosticsError(diags)
+	}
+	return value, nil
+}
+
+%[9]s`
+	return fmt.Sprintf(
+		fmtStr,
+		p.ModelTypeName(),
+		p.path(),
+		fields.String(),
+		attributeTypes.String(),
+		toBody.String(),
+		nullVariants.String(),
+		selection,
+		fromBody.String(),
+		nestedModels.String(),
+		p.conversionFuncName("ToBody"),
+		p.conversionFuncName("FromBody"),
+		mismatch,
+	) + p.renderNullUnknownModel()
+}
+
+// renderNullUnknownModel generates a function replacing the unknown values of computed attributes nested in a value of this object property with null.
+// It is only generated for resources, and only if the object contains computed attributes.
+func (p *augmentedPropertySchema) renderNullUnknownModel() string {
+	if p.parent.IsDataSource || !p.containsComputed() {
+		return ""
+	}
+	nullUnknown := strings.Builder{}
+	for _, nested := range p.properties {
+		code := nested.renderNullUnknown("model."+casing.Camel(nested.Name), fmt.Sprintf(`return value, fmt.Errorf("%s: %%w", err)`, nested.Name))
+		if code != "" {
+			nullUnknown.WriteString(code)
+			nullUnknown.WriteRune('\n')
+		}
+	}
+
+	fmtStr := `
+// %[2]s replaces the unknown values of computed attributes nested in a value of the attribute with null.
+func %[2]s(ctx context.Context, value types.Object) (types.Object, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return value, nil
+	}
+	var model %[1]s
+	if diags := value.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return value, diagnosticsError(diags)
+	}
+	%[3]s
+	result, diags := types.ObjectValueFrom(ctx, model.attributeTypes(), model)
+	if diags.HasError() {
+		return value, diagnosticsError(diags)
+	}
+	return result, nil
+}
+`
+	return fmt.Sprintf(fmtStr, p.ModelTypeName(), p.conversionFuncName("NullUnknown"), nullUnknown.String())
+}
+
+// renderFillBody generates code to populate this property in the body of the given request, which is an augmentedPropertySchema*Request flag.
+// Properties that the request does not declare are skipped, and null or unknown values are left out.
+func (p *augmentedPropertySchema) renderFillBody(request int) string {
+	if !p.isInRequestOf(request) {
+		return ""
+	}
 	switch p.GetTopSchemaType() {
 	case propertyTypeAny:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, err := anyToDynamic(%[2]sRaw); err == nil {
-		data.%[3]s = %[2]sValue
-	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", err.Error())
-	}
+		fmtStr := `if %[2]sUnpacked, err := UnpackDynamicType(&data.%[3]s, ctx); err != nil {
+	resp.Diagnostics.AddError("cannot unpack data", fmt.Sprintf("cannot unpack data of property '%[1]s' due to error: %%v", err))
+} else if %[2]sUnpacked != nil {
+	requestBody["%[1]s"] = %[2]sUnpacked
+}`
+		return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name))
+	default:
+		fmtStr := `if %[2]sBody, err := %[4]s; err != nil {
+	resp.Diagnostics.AddError("cannot convert data", fmt.Sprintf("cannot convert data of property '%[1]s' due to error: %%v", err))
+} else if %[2]sBody != nil {
+	requestBody["%[1]s"] = %[2]sBody
 }`
+		return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name), p.renderToBody("data."+casing.Camel(p.Name)))
+	}
+}
+
+// RenderNullUnknownValue generates code to replace unknown values of this property and of computed attributes nested in it,
+// which the API did not return, with null.
+// Terraform rejects unknown values after a resource was created or updated.
+func (p *augmentedPropertySchema) RenderNullUnknownValue() string {
+	onError := fmt.Sprintf(`resp.Diagnostics.AddError("cannot convert data", fmt.Sprintf("cannot convert data of property '%s' due to error: %%v", err))`, p.Name)
+	return p.renderNullUnknown("data."+casing.Camel(p.Name), onError)
+}
+
+// renderNullUnknown generates code to replace the given value of this property with null if it is computed and unknown,
+// and otherwise the unknown values of computed attributes nested in it.
+// Errors are handled by the given statement, which can refer to them as err.
+func (p *augmentedPropertySchema) renderNullUnknown(value string, onError string) string {
+	code := strings.Builder{}
+	if p.IsComputed() {
+		code.WriteString(fmt.Sprintf("if %[1]s.IsUnknown() {\n\t%[1]s = %[2]s\n}", value, p.renderNullValue()))
+	}
+	if !p.containsComputed() {
+		return code.String()
+	}
+	if code.Len() > 0 {
+		code.WriteString(" else ")
+	}
+	code.WriteString(fmt.Sprintf("if nullUnknown, err := %[2]s; err != nil {\n\t%[3]s\n} else {\n\t%[1]s = nullUnknown\n}", value, p.renderNullUnknownNested(value), onError))
+	return code.String()
+}
+
+// renderNullUnknownNested generates an expression replacing the unknown values of computed attributes nested in the given value of this property with null.
+func (p *augmentedPropertySchema) renderNullUnknownNested(value string) string {
+	if p.GetTopSchemaType() == propertyTypeObject {
+		return fmt.Sprintf("%s(ctx, %s)", p.conversionFuncName("NullUnknown"), value)
+	}
+	return fmt.Sprintf("nullUnknown%s(ctx, %s, %s)", p.GetTypeType(), value, p.items.renderNullUnknownFunc())
+}
+
+// renderNullUnknownFunc generates a function replacing the unknown values of computed attributes nested in values of this property with null.
+func (p *augmentedPropertySchema) renderNullUnknownFunc() string {
+	if p.GetTopSchemaType() == propertyTypeObject {
+		return p.conversionFuncName("NullUnknown")
+	}
+	return fmt.Sprintf("func(ctx context.Context, value types.%[1]s) (types.%[1]s, error) { return %[2]s }", p.GetTypeType(), p.renderNullUnknownNested("value"))
+}
+
+// containsComputed returns true if the property contains nested attributes that are computed, directly or within nested objects.
+func (p *augmentedPropertySchema) containsComputed() bool {
+	switch p.GetTopSchemaType() {
+	case propertyTypeObject:
+		for i := range p.properties {
+			if p.properties[i].IsComputed() || p.properties[i].containsComputed() {
+				return true
+			}
+		}
+		return false
+	case propertyTypeList, propertyTypeSet, propertyTypeMap:
+		return p.items.containsComputed()
 	default:
-		fmtStr = `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
-	if %[2]sValue, ok := %[2]sRaw.(%[5]s); ok {
-		data.%[3]s = types.%[4]sValue(%[2]sValue)
+		return false
+	}
+}
+
+// renderUpdateDataWithResponse generates code to update Terraform state with this property's value from an API response.
+func (p *augmentedPropertySchema) renderUpdateDataWithResponse() string {
+	fmtStr := `if %[2]sRaw, ok := responseBody["%[1]s"]; ok {
+	if %[2]sValue, err := %[4]s; err == nil {
+		data.%[3]s = %[2]sValue
 	} else {
-		resp.Diagnostics.AddError("failure to set data '%[1]s'", "'%[1]s' field is not of the expected type")
+		resp.Diagnostics.AddError("failure to set data '%[1]s'", err.Error())
 	}
 }`
-	}
-	return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name), p.GetTypeType(), p.GetGoType())
+	return fmt.Sprintf(fmtStr, p.Name, casing.LowerCamel(p.Name), casing.Camel(p.Name), p.renderFromBody(casing.LowerCamel(p.Name)+"Raw"))
+}
+
+// RenderFillCreateBody generates code to populate this property in the API request body during resource creation.
+func (p *augmentedPropertySchema) RenderFillCreateBody() string {
+	return p.renderFillBody(augmentedPropertySchemaCreateRequest)
+}
+
+// RenderUpdateDataWithCreateResponse generates code to update Terraform state with this property's value from the API response.
+func (p *augmentedPropertySchema) RenderUpdateDataWithCreateResponse() string {
+	return p.renderUpdateDataWithResponse()
+}
+
+// RenderFillUpdateBody generates code to populate this property in the API request body during resource update.
+func (p *augmentedPropertySchema) RenderFillUpdateBody() string {
+	return p.renderFillBody(augmentedPropertySchemaUpdateRequest)
+}
+
+// RenderUpdateDataWithUpdateResponse generates code to update Terraform state with this property's value from the API response.
+func (p *augmentedPropertySchema) RenderUpdateDataWithUpdateResponse() string {
+	return p.renderUpdateDataWithResponse()
+}
+
+// RenderUpdateDataWithReadResponse generates code to update Terraform state with this property's value from the API response.
+func (p *augmentedPropertySchema) RenderUpdateDataWithReadResponse() string {
+	return p.renderUpdateDataWithResponse()
 }
@@ -0,0 +1,201 @@
+package code_generator
+
+import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
+	"atollk/terraform-api-provider-generator/internal/provider_spec"
+	"strings"
+	"testing"
+)
+
+// findProperty returns the property at the given dot-separated path, where "[]" steps into the items of a collection.
+func findProperty(t *testing.T, properties []augmentedPropertySchema, path string) *augmentedPropertySchema {
+	t.Helper()
+	var current *augmentedPropertySchema
+	for _, step := range strings.Split(path, ".") {
+		if step == "[]" {
+			if current == nil || current.items == nil {
+				t.Fatalf("property at %s has no items", path)
+			}
+			current = current.items
+			continue
+		}
+		if current != nil {
+			properties = current.properties
+		}
+		current = nil
+		for i := range properties {
+			if properties[i].Name == step {
+				current = &properties[i]
+			}
+		}
+		if current == nil {
+			t.Fatalf("property %s not found", path)
+		}
+	}
+	return current
+}
+
+func TestNestingDepthOfRecursiveSchema(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_recursive.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		defaults provider_spec.GlobalDefaults
+		// schemaTypes maps property paths to the schema types of their attributes.
+		schemaTypes map[string]string
+	}{
+		{
+			name:     "default depth",
+			defaults: provider_spec.GlobalDefaults{},
+			schemaTypes: map[string]string{
+				"category":          "SingleNestedAttribute",
+				"category.children": "ListNestedAttribute",
+				"category.children.[].children.[].children":             "ListNestedAttribute",
+				"category.parent.parent.parent.parent":                  "DynamicAttribute",
+				"category.children.[].children.[].children.[].children": "ListAttribute",
+			},
+		},
+		{
+			name:     "dynamic fallback",
+			defaults: provider_spec.GlobalDefaults{MaxNestingDepth: 3},
+			schemaTypes: map[string]string{
+				"category.children":                "ListNestedAttribute",
+				"category.children.[].children":    "ListAttribute",
+				"category.children.[].children.[]": "StringAttribute",
+				"category.parent":                  "SingleNestedAttribute",
+				"category.parent.parent":           "DynamicAttribute",
+			},
+		},
+		{
+			name:     "json_string fallback",
+			defaults: provider_spec.GlobalDefaults{MaxNestingDepth: 3, NestingFallback: provider_spec.NestingFallbackJSONString},
+			schemaTypes: map[string]string{
+				"category.children":             "ListNestedAttribute",
+				"category.children.[].children": "ListAttribute",
+				"category.parent.parent":        "StringAttribute",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaults := test.defaults
+			defaults.CreateMethod = "POST"
+			providerInfo := &ProviderInfo{SpecDefaults: &defaults, APISpec: oadoc}
+			resourceInfo := &ResourceInfo{name: "pet", resourceSpec: provider_spec.ResourceSchema{Path: "/pet", ForceRecreate: true}, oadoc: oadoc, providerInfo: providerInfo}
+			renderer := &resourceTemplateRenderer{ProviderInfo: providerInfo, ResourceInfo: resourceInfo}
+
+			properties, err := renderer.getPropertiesFromBodies()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for path, schemaType := range test.schemaTypes {
+				if actual := findProperty(t, properties, path).GetSchemaType(); actual != schemaType {
+					t.Errorf("%s: expected %s, got %s", path, schemaType, actual)
+				}
+			}
+			if !providerInfo.warned["pet/#/components/schemas/Category"] {
+				t.Errorf("recursive schema was not reported, warnings: %v", providerInfo.warned)
+			}
+		})
+	}
+}
+
+func TestNullUnknownNestedComputedAttributes(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_nested_computed.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerInfo := &ProviderInfo{SpecDefaults: &provider_spec.GlobalDefaults{CreateMethod: "POST"}, APISpec: oadoc}
+	resourceInfo := &ResourceInfo{name: "pet", resourceSpec: provider_spec.ResourceSchema{Path: "/pet", ForceRecreate: true}, oadoc: oadoc, providerInfo: providerInfo}
+	renderer := &resourceTemplateRenderer{ProviderInfo: providerInfo, ResourceInfo: resourceInfo}
+
+	// The response omits the objects set in the configuration, so the unknown values of their computed attributes are nulled within them
+	nullUnknownValues, err := renderer.RenderNullUnknownValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"nullUnknown, err := petResourceSettingsNullUnknown(ctx, data.Settings)",
+		"nullUnknown, err := nullUnknownList(ctx, data.Labels, petResourceLabelsNullUnknown)",
+		"nullUnknown, err := nullUnknownMap(ctx, data.Annotations, petResourceAnnotationsNullUnknown)",
+	} {
+		if !strings.Contains(nullUnknownValues, expected) {
+			t.Errorf("expected %q in:\n%s", expected, nullUnknownValues)
+		}
+	}
+	if strings.Contains(nullUnknownValues, "data.Name") {
+		t.Errorf("required attribute must not be nulled:\n%s", nullUnknownValues)
+	}
+
+	nestedModels, err := renderer.RenderNestedModels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"func petResourceSettingsNullUnknown(ctx context.Context, value types.Object) (types.Object, error)",
+		"if model.Revision.IsUnknown() {\n\tmodel.Revision = types.Int64Null()\n}",
+		"func petResourceLabelsNullUnknown(ctx context.Context, value types.Object) (types.Object, error)",
+		"func petResourceAnnotationsNullUnknown(ctx context.Context, value types.Object) (types.Object, error)",
+		"if model.Id.IsUnknown() {\n\tmodel.Id = types.Int64Null()\n}",
+	} {
+		if !strings.Contains(nestedModels, expected) {
+			t.Errorf("expected %q in:\n%s", expected, nestedModels)
+		}
+	}
+	for _, unexpected := range []string{"model.Color.IsUnknown()", "model.Key.IsUnknown()", "model.Value.IsUnknown()"} {
+		if strings.Contains(nestedModels, unexpected) {
+			t.Errorf("attribute that is not computed must not be nulled: %q", unexpected)
+		}
+	}
+}
+
+func TestUnionVariants(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_union.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerInfo := &ProviderInfo{SpecDefaults: &provider_spec.GlobalDefaults{CreateMethod: "POST"}, APISpec: oadoc}
+	resourceInfo := &ResourceInfo{name: "pet", resourceSpec: provider_spec.ResourceSchema{Path: "/pet", ForceRecreate: true}, oadoc: oadoc, providerInfo: providerInfo}
+	renderer := &resourceTemplateRenderer{ProviderInfo: providerInfo, ResourceInfo: resourceInfo}
+
+	// The archive variant is read-only, so the validator is attached to the s3 variant and refers to the http variant only
+	attributeDefinitions, err := renderer.RenderAttributeDefinitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for expected, count := range map[string]int{
+		`exactlyOneVariant("http")`:   1,
+		`exactlyOneVariant("square")`: 1,
+		"exactlyOneVariant(":          2,
+	} {
+		if actual := strings.Count(attributeDefinitions, expected); actual != count {
+			t.Errorf("expected %q %d times, got %d times in:\n%s", expected, count, actual, attributeDefinitions)
+		}
+	}
+
+	// Responses are decoded by the value of the discriminator if there is one, and by their structure otherwise
+	nestedModels, err := renderer.RenderNestedModels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`switch fields["kind"] {`,
+		`case "archive":`,
+		`case "s3":`,
+		`case "http":`,
+		`return withDiscriminator(body, "kind", "s3"), nil`,
+		`fmt.Errorf("unknown value %v of discriminator 'kind'", fields["kind"])`,
+		"switch matchVariant(fields,\n",
+		`variantShape{properties: []string{"radius"}, required: []string{"radius"}},`,
+		`variantShape{properties: []string{"side"}, required: []string{"side"}},`,
+		"case 0:",
+		"case 1:",
+	} {
+		if !strings.Contains(nestedModels, expected) {
+			t.Errorf("expected %q in:\n%s", expected, nestedModels)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package code_generator
 
 import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
 	"atollk/terraform-api-provider-generator/internal/provider_spec"
 	_ "embed"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"github.com/danielgtaylor/casing"
 	"github.com/kaptinlin/messageformat-go/pkg/logger"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/samber/lo"
 )
@@ -31,33 +33,78 @@ func (r *resourceTemplateRenderer) Name() string {
 	return r.name
 }
 
-// getOperationBodies extracts the request and response schemas for a given OpenAPI operation.
-// It returns the request schema, response schema, and any error encountered.
-func (r *resourceTemplateRenderer) getOperationBodies(path string, operation string) (*base.Schema, *base.Schema, error) {
-	pathObject, present := r.ResourceInfo.OADoc().Model.Paths.PathItems.Get(path)
+// warnOnce logs a warning about the resource, unless a warning with the same key was logged for it before.
+// The properties of the resource are collected for every part of the template and again for its data source, which would repeat their warnings otherwise.
+func (r *resourceTemplateRenderer) warnOnce(key string, message string) {
+	key = fmt.Sprintf("%s/%s", r.ResourceInfo.Name(), key)
+	if r.ProviderInfo.warned == nil {
+		r.ProviderInfo.warned = make(map[string]bool)
+	}
+	if r.ProviderInfo.warned[key] {
+		return
+	}
+	r.ProviderInfo.warned[key] = true
+	logger.Warn(message)
+}
+
+// getOperation looks up the OpenAPI operation for the given path and HTTP method.
+func getOperation(oadoc oas_parser.OADoc, path string, operation string) (*v3.Operation, error) {
+	pathObject, present := oadoc.Model.Paths.PathItems.Get(path)
 	if !present {
-		return nil, nil, errors.Errorf("could not find expected path %s", path)
+		return nil, errors.Errorf("could not find expected path %s", path)
 	}
 	opName := strings.ToLower(operation)
 	op, present := pathObject.GetOperations().Get(opName)
 	if !present {
-		return nil, nil, errors.Errorf("could not find expected operation %s at path %s", opName, path)
+		return nil, errors.Errorf("could not find expected operation %s at path %s", opName, path)
+	}
+	return op, nil
+}
+
+// getSuccessResponse returns the first response of the operation with a 2XX status code, or nil if there is none.
+func getSuccessResponse(op *v3.Operation) *v3.Response {
+	if op.Responses == nil {
+		return nil
+	}
+	for code, response := range op.Responses.Codes.FromOldest() {
+		if strings.HasPrefix(code, "2") {
+			return response
+		}
+	}
+	return nil
+}
+
+// getOperationBodies extracts the request and response schemas for a given OpenAPI operation.
+// The response schema is taken from the first successful response; it is nil if that response has no JSON body.
+// It returns the request schema, response schema, and any error encountered.
+func (r *resourceTemplateRenderer) getOperationBodies(path string, operation string) (*base.Schema, *base.Schema, error) {
+	op, err := getOperation(r.ResourceInfo.OADoc(), path, operation)
+	if err != nil {
+		return nil, nil, err
+	}
+	opName := strings.ToLower(operation)
+	if op.RequestBody == nil || op.RequestBody.Content == nil {
+		return nil, nil, errors.Errorf("could not find request body at operation %s at path %s", opName, path)
 	}
 	requestContent, present := op.RequestBody.Content.Get("application/json")
 	if !present {
 		return nil, nil, errors.Errorf("could not find expected request content type %s at operation %s at path %s", "application/json", opName, path)
 	}
-	responseContent, present := op.RequestBody.Content.Get("application/json")
-	if !present {
-		return nil, nil, errors.Errorf("could not find expected response content type %s at operation %s at path %s", "application/json", opName, path)
+	requestSchema, err := buildSchema(requestContent.Schema)
+	if err != nil {
+		return nil, nil, errors.Errorf("request body of operation %s at path %s: %w", opName, path, err)
 	}
-	requestSchema := requestContent.Schema.Schema()
-	if requestSchema == nil {
-		return nil, nil, errors.Errorf("could not build schema: %w", requestContent.Schema.GetBuildError())
+	response := getSuccessResponse(op)
+	if response == nil || response.Content == nil {
+		return requestSchema, nil, nil
 	}
-	responseSchema := responseContent.Schema.Schema()
-	if responseSchema == nil {
-		return nil, nil, errors.Errorf("could not build schema: %w", responseContent.Schema.GetBuildError())
+	responseContent, present := response.Content.Get("application/json")
+	if !present {
+		return requestSchema, nil, nil
+	}
+	responseSchema, err := buildSchema(responseContent.Schema)
+	if err != nil {
+		return nil, nil, errors.Errorf("response body of operation %s at path %s: %w", opName, path, err)
 	}
 	return requestSchema, responseSchema, nil
 }
@@ -114,6 +161,62 @@ func (r *resourceTemplateRenderer) GetReadMethod() string {
 	return r.ResourceInfo.ResourceSpec().GetOperationMethod(provider_spec.Read, r.ProviderInfo.SpecDefaults)
 }
 
+// renderOperationSecurityRequirements generates the security requirements of the given operation.
+// Requirements declared on the operation override those declared for the whole document.
+func (r *resourceTemplateRenderer) renderOperationSecurityRequirements(path string, operation string) string {
+	return renderSecurityRequirements(getOperationSecurityRequirements(r.ResourceInfo.OADoc(), path, operation))
+}
+
+// RenderCreateSecurityRequirements generates the security requirements of the create operation.
+func (r *resourceTemplateRenderer) RenderCreateSecurityRequirements() string {
+	return r.renderOperationSecurityRequirements(r.GetCreatePath(), r.GetCreateMethod())
+}
+
+// RenderUpdateSecurityRequirements generates the security requirements of the update operation.
+func (r *resourceTemplateRenderer) RenderUpdateSecurityRequirements() string {
+	return r.renderOperationSecurityRequirements(r.GetUpdatePath(), r.GetUpdateMethod())
+}
+
+// RenderDestroySecurityRequirements generates the security requirements of the delete operation.
+func (r *resourceTemplateRenderer) RenderDestroySecurityRequirements() string {
+	return r.renderOperationSecurityRequirements(r.GetDestroyPath(), r.GetDestroyMethod())
+}
+
+// RenderReadSecurityRequirements generates the security requirements of the read operation.
+func (r *resourceTemplateRenderer) RenderReadSecurityRequirements() string {
+	return r.renderOperationSecurityRequirements(r.GetReadPath(), r.GetReadMethod())
+}
+
+// renderOperationBaseURL generates an expression for the base URL of the given operation.
+// Servers declared for the operation or its path override the base URL configured in the provider.
+func (r *resourceTemplateRenderer) renderOperationBaseURL(path string, operation string) string {
+	serverURL := getOperationServerURL(r.ResourceInfo.OADoc(), path, operation)
+	if serverURL == "" {
+		return "r.baseURL"
+	}
+	return fmt.Sprintf("r.client.serverURL(%q)", serverURL)
+}
+
+// RenderCreateBaseURL generates an expression for the base URL of the create operation.
+func (r *resourceTemplateRenderer) RenderCreateBaseURL() string {
+	return r.renderOperationBaseURL(r.GetCreatePath(), r.GetCreateMethod())
+}
+
+// RenderUpdateBaseURL generates an expression for the base URL of the update operation.
+func (r *resourceTemplateRenderer) RenderUpdateBaseURL() string {
+	return r.renderOperationBaseURL(r.GetUpdatePath(), r.GetUpdateMethod())
+}
+
+// RenderDestroyBaseURL generates an expression for the base URL of the delete operation.
+func (r *resourceTemplateRenderer) RenderDestroyBaseURL() string {
+	return r.renderOperationBaseURL(r.GetDestroyPath(), r.GetDestroyMethod())
+}
+
+// RenderReadBaseURL generates an expression for the base URL of the read operation.
+func (r *resourceTemplateRenderer) RenderReadBaseURL() string {
+	return r.renderOperationBaseURL(r.GetReadPath(), r.GetReadMethod())
+}
+
 // getPropertiesFromBodies extracts and merges properties from create and update request/response bodies.
 // It returns a list of augmented property schemas with metadata about which bodies contain each property.
 func (r *resourceTemplateRenderer) getPropertiesFromBodies() ([]augmentedPropertySchema, error) {
@@ -121,7 +224,7 @@ func (r *resourceTemplateRenderer) getPropertiesFromBodies() ([]augmentedPropert
 	if err != nil {
 		return nil, errors.Errorf("could not get request/response bodies for create: %w", err)
 	}
-	if !slices.Contains(createRequestBody.Type, "object") || !slices.Contains(createResponseBody.Type, "object") {
+	if !slices.Contains(createRequestBody.Type, "object") || (createResponseBody != nil && !slices.Contains(createResponseBody.Type, "object")) {
 		return nil, errors.Errorf("only object types are supported for request/response bodies")
 	}
 	var updateRequestBody *base.Schema
@@ -131,16 +234,20 @@ func (r *resourceTemplateRenderer) getPropertiesFromBodies() ([]augmentedPropert
 		if err != nil {
 			return nil, errors.Errorf("could not get request/response bodies for update: %w", err)
 		}
-		if !slices.Contains(updateRequestBody.Type, "object") || !slices.Contains(updateResponseBody.Type, "object") {
+		if !slices.Contains(updateRequestBody.Type, "object") || (updateResponseBody != nil && !slices.Contains(updateResponseBody.Type, "object")) {
 			return nil, errors.Errorf("only object types are supported for request/response bodies")
 		}
 	}
 	dynamicTypeChecks := map[string][]string{
-		"create request":  createRequestBody.Type,
-		"create response": createResponseBody.Type,
+		"create request": createRequestBody.Type,
+	}
+	if createResponseBody != nil {
+		dynamicTypeChecks["create response"] = createResponseBody.Type
 	}
 	if updateRequestBody != nil {
 		dynamicTypeChecks["update request"] = updateRequestBody.Type
+	}
+	if updateResponseBody != nil {
 		dynamicTypeChecks["update response"] = updateResponseBody.Type
 	}
 	for bodyName, schemaType := range dynamicTypeChecks {
@@ -152,17 +259,23 @@ func (r *resourceTemplateRenderer) getPropertiesFromBodies() ([]augmentedPropert
 
 	propertyMap := orderedmap.New[string, *augmentedPropertySchema]()
 	parsePropertiesForBody := func(bodyName string, bodySchema *base.Schema, flag int) error {
-		for propertyName, propertySchemaProxy := range createRequestBody.Properties.FromOldest() {
-			propertySchema := propertySchemaProxy.Schema()
-			if propertySchema == nil {
-				return errors.Errorf("could not get schema for property %s in %s body", propertyName, bodyName)
+		if bodySchema == nil {
+			return nil
+		}
+		for propertyName, propertySchemaProxy := range bodySchema.Properties.FromOldest() {
+			propertySchema, err := buildSchema(propertySchemaProxy)
+			if err != nil {
+				return errors.Errorf("could not get schema for property %s in %s body: %w", propertyName, bodyName, err)
 			}
 			entry, exists := propertyMap.Get(propertyName)
 			if !exists {
-				entry = &augmentedPropertySchema{Name: propertyName, Schema: propertySchema, parent: r}
+				entry = &augmentedPropertySchema{Name: propertyName, Schema: propertySchema, parent: r, reference: propertySchemaProxy.GetReference()}
 				propertyMap.Set(propertyName, entry)
 			}
 			entry.containedInBodyFlag = entry.containedInBodyFlag | flag
+			if flag == augmentedPropertySchemaCreateRequest && slices.Contains(bodySchema.Required, propertyName) {
+				entry.required = true
+			}
 		}
 		return nil
 	}
@@ -187,11 +300,66 @@ func (r *resourceTemplateRenderer) getPropertiesFromBodies() ([]augmentedPropert
 
 	var result []augmentedPropertySchema
 	for prop := range propertyMap.ValuesFromOldest() {
+		if err := prop.resolveNestedProperties(); err != nil {
+			return nil, err
+		}
 		result = append(result, *prop)
 	}
+	if err := r.checkAttributeSettings(result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+// checkAttributeSettings verifies that the attribute settings and sensitive paths of the resource refer to existing properties, and that the settings result in valid flags.
+func (r *resourceTemplateRenderer) checkAttributeSettings(properties []augmentedPropertySchema) error {
+	settings := r.ResourceInfo.ResourceSpec().Attributes
+	sensitive := r.ResourceInfo.ResourceSpec().Sensitive
+	if len(settings) == 0 && len(sensitive) == 0 {
+		return nil
+	}
+	found := make(map[string]bool)
+	var check func(p *augmentedPropertySchema) error
+	check = func(p *augmentedPropertySchema) error {
+		found[p.path()] = true
+		if _, present := settings[p.path()]; present && !p.isItem {
+			if p.IsRequired() && (p.IsOptional() || p.IsComputed()) {
+				return errors.Errorf("attribute '%s' of resource '%s' cannot be optional or computed while it is required", p.path(), r.ResourceInfo.Name())
+			}
+			if !p.IsRequired() && !p.IsOptional() && !p.IsComputed() {
+				return errors.Errorf("attribute '%s' of resource '%s' must be required, optional or computed", p.path(), r.ResourceInfo.Name())
+			}
+		}
+		if p.items != nil {
+			if err := check(p.items); err != nil {
+				return err
+			}
+		}
+		for i := range p.properties {
+			if err := check(&p.properties[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := range properties {
+		if err := check(&properties[i]); err != nil {
+			return err
+		}
+	}
+	for path := range settings {
+		if !found[path] {
+			return errors.Errorf("attribute settings of resource '%s' refer to '%s', which is not a property of the resource", r.ResourceInfo.Name(), path)
+		}
+	}
+	for _, path := range sensitive {
+		if !found[path] {
+			return errors.Errorf("sensitive paths of resource '%s' include '%s', which is not a property of the resource", r.ResourceInfo.Name(), path)
+		}
+	}
+	return nil
+}
+
 // renderForEachProp applies a rendering function to each property and concatenates the results.
 func (r *resourceTemplateRenderer) renderForEachProp(f func(*augmentedPropertySchema) string) (string, error) {
 	properties, err := r.getPropertiesFromBodies()
@@ -207,16 +375,83 @@ func (r *resourceTemplateRenderer) renderForEachProp(f func(*augmentedPropertySc
 	return result.String(), nil
 }
 
-func (r *resourceTemplateRenderer) RenderRequestHeaders() (string, error) {
-	result := &strings.Builder{}
-	headers := r.ProviderInfo.SpecDefaults.Headers
-	if headers != nil {
-		for name, value := range headers.OtherProps {
-			result.WriteString(fmt.Sprintf(`httpReq.Header.Set("%s", "%s")`, name, value))
-			result.WriteRune('\n')
+// RenderRetryPolicy generates a RetryPolicy expression from the retry settings of the resource and the global defaults.
+func (r *resourceTemplateRenderer) RenderRetryPolicy() string {
+	return renderRetryPolicy(r.ResourceInfo.ResourceSpec().GetRetryPolicy(r.ProviderInfo.SpecDefaults))
+}
+
+// IsDebug returns true if the requests and responses of the resource are logged.
+func (r *resourceTemplateRenderer) IsDebug() bool {
+	return r.ResourceInfo.ResourceSpec().Debug || r.ProviderInfo.SpecDefaults.Debug
+}
+
+// RenderSensitiveKeys generates a []string expression with the body keys of all sensitive properties, including nested ones.
+// Logs redact the values of these keys at any depth, so a key of a nested property redacts other properties with the same key as well.
+func (r *resourceTemplateRenderer) RenderSensitiveKeys() (string, error) {
+	properties, err := r.getPropertiesFromBodies()
+	if err != nil {
+		return "", errors.Errorf("could not get body properties: %w", err)
+	}
+	var keys []string
+	for _, prop := range properties {
+		for _, key := range prop.sensitiveKeys() {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
 		}
 	}
-	return result.String(), nil
+	if keys == nil {
+		return "nil", nil
+	}
+	return fmt.Sprintf("%#v", keys), nil
+}
+
+// RenderResponseProcessors generates a []ResponseProcessor expression with the steps applied to response bodies of the resource.
+func (r *resourceTemplateRenderer) RenderResponseProcessors() string {
+	var processors []string
+	if prefix := r.ResourceInfo.ResourceSpec().GetXssiPrefix(r.ProviderInfo.SpecDefaults); prefix != "" {
+		processors = append(processors, fmt.Sprintf("stripPrefix(%q)", prefix))
+	}
+	if key := r.ResourceInfo.ResourceSpec().GetResponseObjectKey(r.ProviderInfo.SpecDefaults); key != "" {
+		processors = append(processors, fmt.Sprintf("extractKey(%q)", key))
+	}
+	if processors == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("[]ResponseProcessor{%s}", strings.Join(processors, ", "))
+}
+
+// renderRetryPolicy generates a RetryPolicy expression from the given retry settings, filling in the defaults for unset values.
+func renderRetryPolicy(policy provider_spec.RetryPolicy) string {
+	maxAttempts := 3
+	if policy.MaxAttempts != nil {
+		maxAttempts = *policy.MaxAttempts
+	}
+	minBackoff := 1.0
+	if policy.MinBackoff != nil {
+		minBackoff = *policy.MinBackoff
+	}
+	maxBackoff := 30.0
+	if policy.MaxBackoff != nil {
+		maxBackoff = *policy.MaxBackoff
+	}
+	retryableStatusCodes := []int{429, 502, 503, 504}
+	if policy.RetryableStatusCodes != nil {
+		retryableStatusCodes = policy.RetryableStatusCodes
+	}
+	retryNonIdempotent := false
+	if policy.RetryNonIdempotent != nil {
+		retryNonIdempotent = *policy.RetryNonIdempotent
+	}
+	statusCodes := lo.Map(retryableStatusCodes, func(code int, _ int) string { return fmt.Sprintf("%d", code) })
+	return fmt.Sprintf(
+		"RetryPolicy{MaxAttempts: %d, MinBackoff: %d * time.Millisecond, MaxBackoff: %d * time.Millisecond, RetryableStatusCodes: []int64{%s}, RetryNonIdempotent: %t}",
+		maxAttempts,
+		int64(minBackoff*1000),
+		int64(maxBackoff*1000),
+		strings.Join(statusCodes, ", "),
+		retryNonIdempotent,
+	)
 }
 
 // RenderModelDataFields generates Go struct field declarations for the Terraform resource model.
@@ -228,6 +463,15 @@ func (r *resourceTemplateRenderer) RenderModelDataFields() (string, error) {
 	)
 }
 
+// RenderNestedModels generates the model structs of all nested object attributes of the resource.
+func (r *resourceTemplateRenderer) RenderNestedModels() (string, error) {
+	return r.renderForEachProp(
+		func(prop *augmentedPropertySchema) string {
+			return prop.RenderNestedModels()
+		},
+	)
+}
+
 // RenderAttributeDefinitions generates Terraform schema attribute definitions for all resource properties.
 func (r *resourceTemplateRenderer) RenderAttributeDefinitions() (string, error) {
 	return r.renderForEachProp(
@@ -237,12 +481,8 @@ func (r *resourceTemplateRenderer) RenderAttributeDefinitions() (string, error)
 	)
 }
 
-func (r *resourceTemplateRenderer) RenderCreateRequestUrlExpression() (string, error) {
-	properties, err := r.getPropertiesFromBodies()
-	if err != nil {
-		return "", errors.Errorf("could not get body properties: %w", err)
-	}
-
+// idAttribute returns the name of the property that identifies objects of the resource.
+func (r *resourceTemplateRenderer) idAttribute() string {
 	idAttribute := r.ResourceInfo.ResourceSpec().IdAttribute
 	if idAttribute == "" {
 		idAttribute = r.ProviderInfo.SpecDefaults.IdAttribute
@@ -250,13 +490,23 @@ func (r *resourceTemplateRenderer) RenderCreateRequestUrlExpression() (string, e
 	if idAttribute == "" {
 		idAttribute = "id"
 	}
+	return idAttribute
+}
+
+func (r *resourceTemplateRenderer) RenderCreateRequestUrlExpression() (string, error) {
+	properties, err := r.getPropertiesFromBodies()
+	if err != nil {
+		return "", errors.Errorf("could not get body properties: %w", err)
+	}
+
+	idAttribute := r.idAttribute()
 	idProp, found := lo.Find(properties, func(p augmentedPropertySchema) bool { return p.Name == idAttribute })
 	if !found {
 		return "", fmt.Errorf("could not find property with id_attribute name %s", idAttribute)
 	}
 
-	fmtStr := `strings.Replace(fmt.Sprintf("%%s%s", r.baseURL), "{%s}", fmt.Sprintf("%%v", data.%s.Value%s()), -1)`
-	result := fmt.Sprintf(fmtStr, r.ResourceInfo.ResourceSpec().GetOperationPath(provider_spec.Create, r.ProviderInfo.SpecDefaults), r.ResourceInfo.ResourceSpec().IdAttributePath, casing.Camel(idProp.Name), idProp.GetTypeType())
+	fmtStr := `strings.Replace(fmt.Sprintf("%%s%s", %s), "{%s}", fmt.Sprintf("%%v", data.%s.Value%s()), -1)`
+	result := fmt.Sprintf(fmtStr, r.ResourceInfo.ResourceSpec().GetOperationPath(provider_spec.Create, r.ProviderInfo.SpecDefaults), r.RenderCreateBaseURL(), r.ResourceInfo.ResourceSpec().IdAttributePath, casing.Camel(idProp.Name), idProp.GetTypeType())
 	return result, nil
 }
 
@@ -296,6 +546,40 @@ func (r *resourceTemplateRenderer) RenderUpdateDataWithUpdateResponse() (string,
 	)
 }
 
+// RenderNullUnknownValues generates code to replace the unknown values of computed attributes with null once the API has responded.
+func (r *resourceTemplateRenderer) RenderNullUnknownValues() (string, error) {
+	return r.renderForEachProp(
+		func(prop *augmentedPropertySchema) string {
+			return prop.RenderNullUnknownValue()
+		},
+	)
+}
+
+// RenderBodyPaths generates a *bodyPaths expression mapping locations in request bodies to the attributes holding their values.
+func (r *resourceTemplateRenderer) RenderBodyPaths() (string, error) {
+	entries, err := r.renderForEachProp(
+		func(prop *augmentedPropertySchema) string {
+			return prop.RenderBodyPathEntry()
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("&bodyPaths{attributes: map[string]bodyAttribute{\n%s}}", entries), nil
+}
+
+// RenderRequestValidation generates the RequestValidation constant selecting how request bodies of the resource that do not match the OpenAPI document are reported.
+func (r *resourceTemplateRenderer) RenderRequestValidation() string {
+	switch r.ResourceInfo.ResourceSpec().GetRequestValidation(r.ProviderInfo.SpecDefaults) {
+	case provider_spec.RequestValidationWarning:
+		return "RequestValidationWarning"
+	case provider_spec.RequestValidationOff:
+		return "RequestValidationOff"
+	default:
+		return "RequestValidationError"
+	}
+}
+
 // RenderUpdateDataWithReadResponse generates code to update Terraform state from API response data after resource creation.
 func (r *resourceTemplateRenderer) RenderUpdateDataWithReadResponse() (string, error) {
 	return r.renderForEachProp(
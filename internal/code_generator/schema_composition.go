@@ -0,0 +1,198 @@
+package code_generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/casing"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// unionVariant is a variant of a polymorphic schema declared by oneOf or anyOf.
+type unionVariant struct {
+	// Name is the name of the attribute holding values of the variant.
+	Name   string
+	Schema *base.Schema
+	// DiscriminatorValue is the value of the discriminator property that selects the variant, if the schema has a discriminator.
+	DiscriminatorValue string
+	// Reference is the $ref through which the variant is declared, or an empty string if it is declared inline.
+	Reference string
+}
+
+// getUnionVariants returns the variants of a polymorphic schema, or nil if the schema is not polymorphic.
+// Variants that only allow null are skipped, since every attribute can be null.
+// Variants are named after their discriminator value, the schema they refer to, or their title, in that order.
+func getUnionVariants(schema *base.Schema) ([]unionVariant, error) {
+	proxies := schema.OneOf
+	if len(proxies) == 0 {
+		proxies = schema.AnyOf
+	}
+	var variants []unionVariant
+	for i, proxy := range proxies {
+		variantSchema, err := buildSchema(proxy)
+		if err != nil {
+			return nil, errors.Errorf("variant %d: %w", i+1, err)
+		}
+		if slices.Equal(variantSchema.Type, []string{"null"}) {
+			continue
+		}
+		variant := unionVariant{Schema: variantSchema, Reference: proxy.GetReference()}
+		if schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+			variant.DiscriminatorValue = getDiscriminatorValue(schema.Discriminator, proxy, variantSchema)
+			if variant.DiscriminatorValue == "" {
+				return nil, errors.Errorf("variant %d: cannot determine the value of the discriminator '%s'", i+1, schema.Discriminator.PropertyName)
+			}
+		}
+		variant.Name = variant.DiscriminatorValue
+		if variant.Name == "" {
+			variant.Name = getReferencedSchemaName(proxy)
+		}
+		if variant.Name == "" {
+			variant.Name = variantSchema.Title
+		}
+		if variant.Name == "" {
+			variant.Name = fmt.Sprintf("variant_%d", i+1)
+		}
+		variant.Name = casing.Snake(variant.Name)
+		if slices.ContainsFunc(variants, func(v unionVariant) bool { return v.Name == variant.Name }) {
+			return nil, errors.Errorf("variant %d: more than one variant is named '%s'", i+1, variant.Name)
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// getDiscriminatorValue returns the discriminator value that selects the given variant.
+// It is taken from the mapping of the discriminator, the name of the referenced schema, or a single allowed value of the discriminator property.
+func getDiscriminatorValue(discriminator *base.Discriminator, proxy *base.SchemaProxy, schema *base.Schema) string {
+	name := getReferencedSchemaName(proxy)
+	for value, target := range discriminator.Mapping.FromOldest() {
+		if (proxy.IsReference() && target == proxy.GetReference()) || (name != "" && target == name) {
+			return value
+		}
+	}
+	if name != "" {
+		return name
+	}
+	if schema.Properties == nil {
+		return ""
+	}
+	propertyProxy, present := schema.Properties.Get(discriminator.PropertyName)
+	if !present {
+		return ""
+	}
+	propertySchema := propertyProxy.Schema()
+	if propertySchema == nil {
+		return ""
+	}
+	if propertySchema.Const != nil {
+		return propertySchema.Const.Value
+	}
+	if len(propertySchema.Enum) == 1 {
+		return propertySchema.Enum[0].Value
+	}
+	return ""
+}
+
+// getReferencedSchemaName returns the name of the schema a proxy refers to, or an empty string if it is defined inline.
+func getReferencedSchemaName(proxy *base.SchemaProxy) string {
+	if !proxy.IsReference() {
+		return ""
+	}
+	reference := proxy.GetReference()
+	return reference[strings.LastIndex(reference, "/")+1:]
+}
+
+// buildSchema builds the schema behind a proxy and merges its allOf subschemas into it.
+func buildSchema(proxy *base.SchemaProxy) (*base.Schema, error) {
+	return buildSubschema(proxy, nil)
+}
+
+// buildSubschema builds the schema behind a proxy that is an allOf subschema of the schemas with the given references.
+// A schema that is its own subschema is an error, since merging it would never end.
+func buildSubschema(proxy *base.SchemaProxy, references []string) (*base.Schema, error) {
+	if proxy.IsReference() {
+		if slices.Contains(references, proxy.GetReference()) {
+			return nil, errors.Errorf("schema '%s' includes itself through allOf", proxy.GetReference())
+		}
+		references = append(slices.Clip(references), proxy.GetReference())
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil, errors.Errorf("could not build schema: %w", proxy.GetBuildError())
+	}
+	return mergeAllOf(schema, references)
+}
+
+// mergeAllOf returns a copy of the schema with the properties, required lists and types of its allOf subschemas merged into it.
+// Properties of the subschemas come first, in order, followed by the properties of the schema itself.
+// A property defined differently by two schemas is an error.
+func mergeAllOf(schema *base.Schema, references []string) (*base.Schema, error) {
+	if len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = orderedmap.New[string, *base.SchemaProxy]()
+	merged.Required = nil
+
+	merge := func(source *base.Schema) error {
+		for name, property := range source.Properties.FromOldest() {
+			existing, present := merged.Properties.Get(name)
+			if !present {
+				merged.Properties.Set(name, property)
+				continue
+			}
+			if !isSameSchema(existing, property) {
+				return errors.Errorf("property '%s' has conflicting definitions in allOf", name)
+			}
+		}
+		for _, name := range source.Required {
+			if !slices.Contains(merged.Required, name) {
+				merged.Required = append(merged.Required, name)
+			}
+		}
+		if len(source.Type) > 0 {
+			if len(merged.Type) > 0 && !slices.Equal(merged.Type, source.Type) {
+				return errors.Errorf("schema types %v and %v in allOf conflict", merged.Type, source.Type)
+			}
+			merged.Type = source.Type
+		}
+		if merged.AdditionalProperties == nil {
+			merged.AdditionalProperties = source.AdditionalProperties
+		}
+		if merged.Description == "" {
+			merged.Description = source.Description
+		}
+		return nil
+	}
+
+	for i, proxy := range schema.AllOf {
+		subschema, err := buildSubschema(proxy, references)
+		if err != nil {
+			return nil, errors.Errorf("allOf[%d]: %w", i, err)
+		}
+		if err := merge(subschema); err != nil {
+			return nil, err
+		}
+	}
+	if err := merge(schema); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// isSameSchema returns true if both proxies refer to the same schema or to schemas with the same content.
+func isSameSchema(a *base.SchemaProxy, b *base.SchemaProxy) bool {
+	if a.IsReference() && b.IsReference() && a.GetReference() == b.GetReference() {
+		return true
+	}
+	schemaA, schemaB := a.Schema(), b.Schema()
+	if schemaA == nil || schemaB == nil {
+		return false
+	}
+	return schemaA.GoLow().Hash() == schemaB.GoLow().Hash()
+}
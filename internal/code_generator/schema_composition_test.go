@@ -0,0 +1,173 @@
+package code_generator
+
+import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// buildComponentSchema builds the schema of the component with the given name and merges its allOf subschemas into it.
+func buildComponentSchema(t *testing.T, oadoc oas_parser.OADoc, name string) (*base.Schema, error) {
+	t.Helper()
+	proxy, present := oadoc.Model.Components.Schemas.Get(name)
+	if !present {
+		t.Fatalf("schema %s not found", name)
+	}
+	return buildSchema(proxy)
+}
+
+func TestMergeAllOf(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_allof.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		schema     string
+		properties []string
+		required   []string
+		// err is a part of the expected error, or empty if the subschemas can be merged
+		err string
+	}{
+		{schema: "Merged", properties: []string{"id", "name", "tag"}, required: []string{"id", "name"}},
+		{schema: "Nested", properties: []string{"id", "name", "tag", "extra"}, required: []string{"id", "name"}},
+		{schema: "SameReference", properties: []string{"id", "name", "other"}, required: []string{"id"}},
+		{schema: "TwiceReferenced", properties: []string{"id", "name"}, required: []string{"id"}},
+		{schema: "ConflictingProperty", err: "property 'id' has conflicting definitions in allOf"},
+		{schema: "ConflictingType", err: "schema types [object] and [array] in allOf conflict"},
+		// Components are not built through a reference, so cycles are detected at the first schema reached twice through $ref
+		{schema: "SelfReference", err: "schema '#/components/schemas/SelfReference' includes itself through allOf"},
+		{schema: "IndirectSelfReference", err: "schema '#/components/schemas/IndirectSelfReferenceSubschema' includes itself through allOf"},
+	}
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			schema, err := buildComponentSchema(t, oadoc, test.schema)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(schema.AllOf) != 0 {
+				t.Errorf("allOf was not removed from the merged schema")
+			}
+			var properties []string
+			for name := range schema.Properties.KeysFromOldest() {
+				properties = append(properties, name)
+			}
+			if !slices.Equal(properties, test.properties) {
+				t.Errorf("expected properties %v, got %v", test.properties, properties)
+			}
+			if !slices.Equal(schema.Required, test.required) {
+				t.Errorf("expected required properties %v, got %v", test.required, schema.Required)
+			}
+			// All merged schemas inherit the type and the additional properties of Base
+			if !slices.Equal(schema.Type, []string{"object"}) {
+				t.Errorf("expected type object, got %v", schema.Type)
+			}
+			if schema.AdditionalProperties == nil || !schema.AdditionalProperties.IsA() || !slices.Equal(schema.AdditionalProperties.A.Schema().Type, []string{"string"}) {
+				t.Errorf("expected additional properties of type string")
+			}
+		})
+	}
+
+	merged, err := buildComponentSchema(t, oadoc, "Nested")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Description != "Base with a required name and a tag" {
+		t.Errorf("description of a subschema was not merged, got %q", merged.Description)
+	}
+}
+
+func TestGetUnionVariants(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_union.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		schema              string
+		names               []string
+		discriminatorValues []string
+		references          []string
+		// err is a part of the expected error, or empty if the variants can be determined
+		err string
+	}{
+		{
+			// The mapping refers to S3Destination by its full reference and to HttpDestination by its bare name
+			schema:              "Destination",
+			names:               []string{"archive", "s3", "http"},
+			discriminatorValues: []string{"archive", "s3", "http"},
+			references:          []string{"", "#/components/schemas/S3Destination", "#/components/schemas/HttpDestination"},
+		},
+		{
+			// The null variant is skipped and the others are named after their titles
+			schema:              "Shape",
+			names:               []string{"circle", "square"},
+			discriminatorValues: []string{"", ""},
+			references:          []string{"", ""},
+		},
+		{
+			schema:              "EnumDiscriminator",
+			names:               []string{"first", "second"},
+			discriminatorValues: []string{"first", "second"},
+			references:          []string{"", ""},
+		},
+		{
+			schema:              "Unnamed",
+			names:               []string{"variant1", "variant2"},
+			discriminatorValues: []string{"", ""},
+			references:          []string{"", ""},
+		},
+		{schema: "MissingDiscriminator", err: "variant 1: cannot determine the value of the discriminator 'kind'"},
+		{schema: "DuplicateVariants", err: "variant 2: more than one variant is named 'same'"},
+	}
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			schema, err := buildComponentSchema(t, oadoc, test.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			variants, err := getUnionVariants(schema)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names, discriminatorValues, references []string
+			for _, variant := range variants {
+				names = append(names, variant.Name)
+				discriminatorValues = append(discriminatorValues, variant.DiscriminatorValue)
+				references = append(references, variant.Reference)
+			}
+			if !slices.Equal(names, test.names) {
+				t.Errorf("expected names %v, got %v", test.names, names)
+			}
+			if !slices.Equal(discriminatorValues, test.discriminatorValues) {
+				t.Errorf("expected discriminator values %v, got %v", test.discriminatorValues, discriminatorValues)
+			}
+			if !slices.Equal(references, test.references) {
+				t.Errorf("expected references %v, got %v", test.references, references)
+			}
+		})
+	}
+
+	pet, err := buildComponentSchema(t, oadoc, "Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variants, err := getUnionVariants(pet); err != nil || variants != nil {
+		t.Errorf("schema without oneOf or anyOf must have no variants, got %v, %v", variants, err)
+	}
+}
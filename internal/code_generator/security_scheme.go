@@ -0,0 +1,130 @@
+package code_generator
+
+import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/casing"
+	"github.com/kaptinlin/messageformat-go/pkg/logger"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+const (
+	securitySchemeTypeApiKey = "apiKey"
+	securitySchemeTypeHttp   = "http"
+	securitySchemeTypeOAuth2 = "oauth2"
+)
+
+// SecuritySchemeInfo describes a security scheme declared in the OpenAPI document that the generated provider supports.
+type SecuritySchemeInfo struct {
+	// Name is the key of the scheme in components.securitySchemes.
+	Name        string
+	Type        string
+	In          string
+	ParamName   string
+	Scheme      string
+	Description string
+}
+
+// HasAttribute returns true if the scheme requires its own provider attribute to hold the credential.
+// Basic auth schemes use the username and password attributes, OAuth2 schemes use the oauth_* attributes.
+func (s *SecuritySchemeInfo) HasAttribute() bool {
+	return s.Type == securitySchemeTypeApiKey || (s.Type == securitySchemeTypeHttp && s.Scheme == "bearer")
+}
+
+// AttributeName returns the name of the provider attribute holding the credential of this scheme.
+func (s *SecuritySchemeInfo) AttributeName() string {
+	return casing.Snake(s.Name)
+}
+
+// AttributeNameCaps returns the attribute name in uppercase, as used for environment variable names.
+func (s *SecuritySchemeInfo) AttributeNameCaps() string {
+	return strings.ToUpper(s.AttributeName())
+}
+
+// FieldName returns the name of the provider model field holding the credential of this scheme.
+func (s *SecuritySchemeInfo) FieldName() string {
+	return fmt.Sprintf("Security%s", casing.Camel(s.Name))
+}
+
+// AttributeDescription returns the Markdown description of the provider attribute for this scheme.
+func (s *SecuritySchemeInfo) AttributeDescription() string {
+	var description string
+	if s.Type == securitySchemeTypeApiKey {
+		description = fmt.Sprintf("The API key sent as %s '%s'.", s.In, s.ParamName)
+	} else {
+		description = "The bearer token sent in the Authorization header."
+	}
+	if s.Description != "" {
+		description = fmt.Sprintf("%s %s", description, s.Description)
+	}
+	return description
+}
+
+// getSecuritySchemes collects all security schemes from the OpenAPI document that the generated provider supports.
+// Unsupported schemes are skipped with a warning.
+func getSecuritySchemes(oadoc oas_parser.OADoc) ([]SecuritySchemeInfo, error) {
+	components := oadoc.Model.Components
+	if components == nil || components.SecuritySchemes == nil {
+		return nil, nil
+	}
+	var result []SecuritySchemeInfo
+	for name, scheme := range components.SecuritySchemes.FromOldest() {
+		info := SecuritySchemeInfo{
+			Name:        name,
+			Type:        scheme.Type,
+			In:          scheme.In,
+			ParamName:   scheme.Name,
+			Scheme:      strings.ToLower(scheme.Scheme),
+			Description: scheme.Description,
+		}
+		switch {
+		case info.Type == securitySchemeTypeApiKey && slices.Contains([]string{"header", "query", "cookie"}, info.In):
+		case info.Type == securitySchemeTypeHttp && slices.Contains([]string{"basic", "bearer"}, info.Scheme):
+		case info.Type == securitySchemeTypeOAuth2:
+		default:
+			logger.Warn(fmt.Sprintf("security scheme '%s' of type '%s' is not supported; it will be ignored", name, info.Type))
+			continue
+		}
+		if info.HasAttribute() && slices.Contains(builtinProviderAttributes, info.AttributeName()) {
+			return nil, errors.Errorf("security scheme '%s' collides with the provider attribute '%s'", name, info.AttributeName())
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// getOperationSecurityRequirements returns the security requirements of the given operation.
+// Requirements declared on the operation override those declared for the whole document,
+// which also apply if the operation is not part of the document.
+func getOperationSecurityRequirements(oadoc oas_parser.OADoc, path string, operation string) []*base.SecurityRequirement {
+	op, err := getOperation(oadoc, path, operation)
+	if err == nil && op.Security != nil {
+		return op.Security
+	}
+	return oadoc.Model.Security
+}
+
+// renderSecurityRequirements generates a []SecurityRequirement expression for the given requirements.
+// A nil slice means that no requirements are declared at all.
+func renderSecurityRequirements(requirements []*base.SecurityRequirement) string {
+	if requirements == nil {
+		return "nil"
+	}
+	result := strings.Builder{}
+	result.WriteString("[]SecurityRequirement{")
+	for _, requirement := range requirements {
+		var schemeNames []string
+		if requirement.Requirements != nil {
+			for schemeName := range requirement.Requirements.KeysFromOldest() {
+				schemeNames = append(schemeNames, fmt.Sprintf("%q", schemeName))
+			}
+		}
+		result.WriteString(fmt.Sprintf("{%s}, ", strings.Join(schemeNames, ", ")))
+	}
+	result.WriteString("}")
+	return result.String()
+}
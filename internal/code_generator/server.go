@@ -0,0 +1,141 @@
+package code_generator
+
+import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/danielgtaylor/casing"
+	"github.com/kaptinlin/messageformat-go/pkg/logger"
+	"github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ServerVariableInfo describes a variable of a server URL declared in the OpenAPI document.
+// Each variable is exposed as a provider attribute.
+type ServerVariableInfo struct {
+	Name        string
+	Default     string
+	Enum        []string
+	Description string
+}
+
+// AttributeName returns the name of the provider attribute holding the value of this variable.
+func (v *ServerVariableInfo) AttributeName() string {
+	return casing.Snake(v.Name)
+}
+
+// AttributeNameCaps returns the attribute name in uppercase, as used for environment variable names.
+func (v *ServerVariableInfo) AttributeNameCaps() string {
+	return strings.ToUpper(v.AttributeName())
+}
+
+// FieldName returns the name of the provider model field holding the value of this variable.
+func (v *ServerVariableInfo) FieldName() string {
+	return fmt.Sprintf("Server%s", casing.Camel(v.Name))
+}
+
+// AttributeDescription returns the Markdown description of the provider attribute for this variable.
+func (v *ServerVariableInfo) AttributeDescription() string {
+	description := fmt.Sprintf("The value of the server URL variable '%s'.", v.Name)
+	if v.Description != "" {
+		description = fmt.Sprintf("%s %s", description, v.Description)
+	}
+	if len(v.Enum) > 0 {
+		description = fmt.Sprintf("%s Must be one of `%s`.", description, strings.Join(v.Enum, "`, `"))
+	}
+	if v.Default != "" {
+		description = fmt.Sprintf("%s Defaults to `%s`.", description, v.Default)
+	}
+	return description
+}
+
+// RenderEnum generates a []string expression with the allowed values of the variable, or nil if any value is allowed.
+func (v *ServerVariableInfo) RenderEnum() string {
+	if len(v.Enum) == 0 {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", v.Enum)
+}
+
+// getServerVariables collects the variables of all server URLs that the generated provider uses.
+// These are the first server of the document and the first server of every path and operation.
+// Variables with the same name are expected to have the same meaning; the first declaration wins.
+func getServerVariables(oadoc oas_parser.OADoc, reservedAttributes []string) ([]ServerVariableInfo, error) {
+	servers := []*v3.Server{firstServer(oadoc.Model.Servers)}
+	if oadoc.Model.Paths != nil {
+		for _, pathItem := range oadoc.Model.Paths.PathItems.FromOldest() {
+			servers = append(servers, firstServer(pathItem.Servers))
+			for _, operation := range pathItem.GetOperations().FromOldest() {
+				servers = append(servers, firstServer(operation.Servers))
+			}
+		}
+	}
+
+	var result []ServerVariableInfo
+	for _, server := range servers {
+		if server == nil || server.Variables == nil {
+			continue
+		}
+		for name, variable := range server.Variables.FromOldest() {
+			index := slices.IndexFunc(result, func(v ServerVariableInfo) bool { return v.Name == name })
+			if index >= 0 {
+				if result[index].Default != variable.Default {
+					logger.Warn(fmt.Sprintf("server variable '%s' is declared with different defaults; using '%s'", name, result[index].Default))
+				}
+				continue
+			}
+			info := ServerVariableInfo{
+				Name:        name,
+				Default:     variable.Default,
+				Enum:        variable.Enum,
+				Description: variable.Description,
+			}
+			if slices.Contains(reservedAttributes, info.AttributeName()) {
+				return nil, errors.Errorf("server variable '%s' collides with the provider attribute '%s'", name, info.AttributeName())
+			}
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// getDefaultServerURL returns the URL of the first server of the document if it is absolute.
+// Relative URLs are relative to the location of the document, which is unknown to the generated provider.
+func getDefaultServerURL(oadoc oas_parser.OADoc) string {
+	server := firstServer(oadoc.Model.Servers)
+	if server == nil || !strings.Contains(server.URL, "://") {
+		return ""
+	}
+	return server.URL
+}
+
+// getOperationServerURL returns the URL of the server declared for the given operation or its path.
+// An empty string means that the operation uses the base URL.
+func getOperationServerURL(oadoc oas_parser.OADoc, path string, operation string) string {
+	if oadoc.Model.Paths == nil {
+		return ""
+	}
+	pathItem, present := oadoc.Model.Paths.PathItems.Get(path)
+	if !present {
+		return ""
+	}
+	if op, present := pathItem.GetOperations().Get(strings.ToLower(operation)); present {
+		if server := firstServer(op.Servers); server != nil {
+			return server.URL
+		}
+	}
+	if server := firstServer(pathItem.Servers); server != nil {
+		return server.URL
+	}
+	return ""
+}
+
+// firstServer returns the first of the given servers, which is the one the generated provider uses, or nil if there is none.
+func firstServer(servers []*v3.Server) *v3.Server {
+	if len(servers) == 0 {
+		return nil
+	}
+	return servers[0]
+}
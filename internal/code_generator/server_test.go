@@ -0,0 +1,78 @@
+package code_generator
+
+import (
+	"atollk/terraform-api-provider-generator/internal/oas_parser"
+	"atollk/terraform-api-provider-generator/internal/provider_spec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetServerVariables(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_servers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the first server of the document, path and operation is used, and the first declaration of a variable wins
+	variables, err := getServerVariables(oadoc, []string{"base_url"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names, defaults []string
+	for _, variable := range variables {
+		names = append(names, variable.Name)
+		defaults = append(defaults, variable.Default)
+	}
+	if expected := []string{"region", "version", "tenant"}; !slices.Equal(names, expected) {
+		t.Errorf("expected variables %v, got %v", expected, names)
+	}
+	if expected := []string{"eu", "v2", "default"}; !slices.Equal(defaults, expected) {
+		t.Errorf("expected defaults %v, got %v", expected, defaults)
+	}
+	if !slices.Equal(variables[0].Enum, []string{"eu", "us"}) || variables[0].Description != "The region of the API." {
+		t.Errorf("enum and description of the first declaration were not kept: %+v", variables[0])
+	}
+
+	_, err = getServerVariables(oadoc, []string{"base_url", "tenant"})
+	if err == nil || !strings.Contains(err.Error(), "server variable 'tenant' collides with the provider attribute 'tenant'") {
+		t.Errorf("expected collision with a provider attribute, got %v", err)
+	}
+}
+
+func TestGetOperationServerURL(t *testing.T) {
+	oadoc, err := oas_parser.Parse("testdata/openapi_servers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path      string
+		operation string
+		expected  string
+	}{
+		// The server of the operation takes precedence over the server of its path
+		{path: "/pet", operation: "GET", expected: "/legacy/{tenant}"},
+		{path: "/pet", operation: "POST", expected: "https://{region}.uploads.example.com/{version}"},
+		// Operations without servers of their own or of their path use the base URL
+		{path: "/store", operation: "GET", expected: ""},
+		{path: "/unknown", operation: "GET", expected: ""},
+	}
+	for _, test := range tests {
+		if actual := getOperationServerURL(oadoc, test.path, test.operation); actual != test.expected {
+			t.Errorf("%s %s: expected %q, got %q", test.operation, test.path, test.expected, actual)
+		}
+	}
+
+	// The provider resolves the servers of its own operations like the resources do, with the server variables substituted at runtime
+	providerInfo := &ProviderInfo{
+		SpecDefaults: &provider_spec.GlobalDefaults{TestPath: "/pet", Login: &provider_spec.LoginOperation{Path: "/store"}},
+		APISpec:      oadoc,
+	}
+	if expected := `resolveServerURL(baseUrl, "/legacy/{tenant}", serverVariables)`; providerInfo.RenderTestBaseURL() != expected {
+		t.Errorf("expected test base URL %s, got %s", expected, providerInfo.RenderTestBaseURL())
+	}
+	if providerInfo.RenderLoginBaseURL() != "baseUrl" {
+		t.Errorf("expected login base URL baseUrl, got %s", providerInfo.RenderLoginBaseURL())
+	}
+}
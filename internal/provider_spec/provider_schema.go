@@ -2,21 +2,86 @@ package provider_spec
 
 type RESTAPIProviderConfiguration struct {
 	GlobalDefaults *GlobalDefaults  `json:"global_defaults,omitempty"`
+	Provider       ProviderIdentity `json:"provider"` // Identity of the generated provider.
 	Resources      *ResourcesSchema `json:"resources,omitempty"`
 }
 
 type GlobalDefaults struct {
+	CertFile      string `json:"cert_file,omitempty"`      // When set with the key_file parameter, the provider will load a client certificate as a file for mTLS authentication. Serves as default for the 'cert_file' provider attribute and its environment variable.
+	CertString    string `json:"cert_string,omitempty"`    // When set with the key_string parameter, the provider will load a client certificate as a string for mTLS authentication. Serves as default for the 'cert_string' provider attribute and its environment variable.
 	CreateMethod  string `json:"create_method,omitempty"`  // Defaults to POST. The HTTP method used to CREATE objects of this type on the API server.
-	Debug         bool   `json:"debug,omitempty"`          // Enabling this will cause lots of debug information to be printed to STDOUT by the API client.
+	Debug         bool   `json:"debug,omitempty"`          // Enabling this will cause the API client to log the requests and responses of all objects, as well as OAuth token requests, through tflog at DEBUG level, which is shown with TF_LOG=DEBUG. Logins are logged with the request that triggers them. Credentials, tokens and sensitive values are redacted.
 	DestroyMethod string `json:"destroy_method,omitempty"` // Defaults to DELETE. The HTTP method used to DELETE objects of this type on the API server.
 	Headers       *struct {
 		// Additional properties, not valided now
 		OtherProps map[string]string `json:",inline"`
-	} `json:"headers,omitempty"` // A map of header names and values to set on all outbound requests. This is useful if you want to use a script via the 'external' provider or provide a pre-approved token or change Content-Type from application/json. If username and password are set and Authorization is one of the headers defined here, the BASIC auth credentials take precedence.
-	IdAttribute  string `json:"id_attribute,omitempty"`  // When set, this key will be used to operate on REST objects. For example, if the ID is set to 'name', changes to the API object will be to http://foo.com/bar/VALUE_OF_NAME.
-	ReadMethod   string `json:"read_method,omitempty"`   // Defaults to GET. The HTTP method used to READ objects of this type on the API server.
-	UpdateMethod string `json:"update_method,omitempty"` // Defaults to PUT. The HTTP method used to UPDATE objects of this type on the API server.
-	Uri          string `json:"uri,omitempty"`           // URI of the REST API endpoint. This serves as the base of all requests.
+	} `json:"headers,omitempty"` // A map of header names and values to set on all outbound requests. This is useful if you want to use a script via the 'external' provider or provide a pre-approved token or change Content-Type from application/json. If username and password are set and Authorization is one of the headers defined here, the BASIC auth credentials take precedence. Headers set through the 'headers' provider attribute or its environment variable are added to these and take precedence.
+	IdAttribute            string                  `json:"id_attribute,omitempty"`             // When set, this key will be used to operate on REST objects. For example, if the ID is set to 'name', changes to the API object will be to http://foo.com/bar/VALUE_OF_NAME.
+	Insecure               bool                    `json:"insecure,omitempty"`                 // When using https, this disables TLS verification of the host. Serves as default for the 'insecure' provider attribute and its environment variable.
+	KeyFile                string                  `json:"key_file,omitempty"`                 // When set with the cert_file parameter, the provider will load a client certificate as a file for mTLS authentication. Note that this mechanism simply delegates to golang's tls.LoadX509KeyPair which does not support passphrase protected private keys. Serves as default for the 'key_file' provider attribute and its environment variable.
+	KeyString              string                  `json:"key_string,omitempty"`               // When set with the cert_string parameter, the provider will load a client certificate as a string for mTLS authentication. Note that this mechanism simply delegates to golang's tls.X509KeyPair which does not support passphrase protected private keys. Serves as default for the 'key_string' provider attribute and its environment variable.
+	Login                  *LoginOperation         `json:"login,omitempty"`                    // An operation that establishes a session, for APIs that authenticate with a session cookie instead of credentials on every request. The provider logs in before the first request and logs in again once if a request is rejected with 401 Unauthorized. While a login operation is configured, username and password are not sent as BASIC auth.
+	MaxNestingDepth        int                     `json:"max_nesting_depth,omitempty"`        // Defaults to 5. The maximum number of levels of nested attributes generated for a property, counting the attribute of the property itself. Objects nested deeper, such as those of recursive schemas, are generated as nesting_fallback attributes instead.
+	NestingFallback        string                  `json:"nesting_fallback,omitempty"`         // Defaults to 'dynamic'. The type of the attributes generated for objects nested deeper than max_nesting_depth. Either 'dynamic', for a Dynamic attribute, or 'json_string', for a String attribute holding the JSON encoding of the object. Since lists, sets and maps cannot contain Dynamic values, objects in them are always generated as 'json_string'.
+	OauthClientCredentials *OauthClientCredentials `json:"oauth_client_credentials,omitempty"` // Configuration for oauth client credential flow using the https://pkg.go.dev/golang.org/x/oauth2 implementation. The values serve as defaults for the 'oauth_*' provider attributes and their environment variables.
+	Password               string                  `json:"password,omitempty"`                 // When set, will use this password for BASIC auth to the API. Serves as default for the 'password' provider attribute and its environment variable.
+	RateLimit              float64                 `json:"rate_limit,omitempty"`               // Set this to limit the number of requests per second made to the API. The limit is shared by all resources and data sources of a provider instance. Serves as default for the 'rate_limit' provider attribute and its environment variable.
+	ReadMethod             string                  `json:"read_method,omitempty"`              // Defaults to GET. The HTTP method used to READ objects of this type on the API server.
+	RequestValidation      string                  `json:"request_validation,omitempty"`       // Defaults to 'error'. How request bodies are checked against the schema of the request body of their operation in the OpenAPI document right before they are sent. 'error' reports violations as errors of the attributes they concern and does not send the request, 'warning' reports them as warnings and sends the request anyway, and 'off' disables the check.
+	ResponseObjectKey      string                  `json:"response_object_key,omitempty"`      // When set, the object is read from this key of the response body instead of the whole body. The format is 'field/field/field'. Example: 'data/item'. Response bodies are processed after stripping the xssi_prefix.
+	Retry                  *RetryPolicy            `json:"retry,omitempty"`                    // Policy for retrying failed requests with exponential backoff. A Retry-After header sent by the API takes precedence over the computed delay, but is limited to max_backoff as well. The values can be overridden per resource and through the 'retry_*' provider attributes.
+	RootCaFile             string                  `json:"root_ca_file,omitempty"`             // When set, the provider will load a root CA certificate as a file to verify the API server. This is useful when the API server is using a certificate issued by an internal PKI. Serves as default for the 'root_ca_file' provider attribute and its environment variable.
+	RootCaString           string                  `json:"root_ca_string,omitempty"`           // When set, the provider will load a root CA certificate as a string to verify the API server. This is useful when the API server is using a certificate issued by an internal PKI. Serves as default for the 'root_ca_string' provider attribute and its environment variable.
+	TestPath               string                  `json:"test_path,omitempty"`                // If set, the provider will issue a read_method request to this path after instantiation requiring a successful response before proceeding. This is useful if your API provides a no-op endpoint that can signal if this provider is configured correctly. Response data will be ignored. A server declared in the OpenAPI document for the path or operation takes precedence over the base URL.
+	Timeout                *float64                `json:"timeout,omitempty"`                  // Defaults to 30. The timeout in seconds for a single request to the API, including reading the response. Set to 0 to disable the timeout. Serves as default for the 'timeout' provider attribute and its environment variable.
+	UpdateMethod           string                  `json:"update_method,omitempty"`            // Defaults to PUT. The HTTP method used to UPDATE objects of this type on the API server.
+	Uri                    string                  `json:"uri,omitempty"`                      // URI of the REST API endpoint. This serves as the base of all requests. If it has no scheme, https is assumed. Serves as default for the 'base_url' provider attribute and its environment variable.
+	UseCookies             bool                    `json:"use_cookies,omitempty"`              // Enable a cookie jar to persist session cookies across requests. The jar is always enabled if a login operation is configured. Serves as default for the 'use_cookies' provider attribute and its environment variable.
+	Username               string                  `json:"username,omitempty"`                 // When set, will use this username for BASIC auth to the API. Serves as default for the 'username' provider attribute and its environment variable.
+	XssiPrefix             string                  `json:"xssi_prefix,omitempty"`              // Trim this XSSI protection prefix, such as )]}', from response bodies, if present, before parsing them.
+}
+
+type AttributeSettings struct {
+	Computed   *bool `json:"computed,omitempty"`    // Overrides whether the value of the attribute may be filled in by the API.
+	Optional   *bool `json:"optional,omitempty"`    // Overrides whether the attribute may be set in the configuration without being required.
+	Required   *bool `json:"required,omitempty"`    // Overrides whether the attribute must be set in the configuration.
+	UseDefault *bool `json:"use_default,omitempty"` // Defaults to true. Whether the default value declared for the property in the OpenAPI document becomes the default of the attribute. Disable this if the server may choose a different value, which would be reverted otherwise.
+}
+
+type LoginOperation struct {
+	Body *struct {
+		// Additional properties, not valided now
+		OtherProps map[string]any `json:",inline"`
+	} `json:"body,omitempty"` // Defaults to {"username": "{username}", "password": "{password}"}. The JSON body sent to the login operation. The strings {username} and {password} are replaced with the credentials configured in the provider.
+	Method string `json:"method,omitempty"` // Defaults to POST. The HTTP method used for the login operation.
+	Path   string `json:"path"`             // The API path on top of the base URL set in the provider that represents the login operation. A server declared in the OpenAPI document for the path or operation takes precedence over the base URL.
+}
+
+type OauthClientCredentials struct {
+	EndpointParams *struct {
+		// Additional properties, not valided now
+		OtherProps map[string]any `json:",inline"`
+	} `json:"endpoint_params,omitempty"` // Additional key/values to pass to the underlying Oauth client library (as EndpointParams)
+	OauthClientId      string   `json:"oauth_client_id,omitempty"`      // The OAuth client ID
+	OauthClientSecret  string   `json:"oauth_client_secret,omitempty"`  // The OAuth client secret
+	OauthScopes        []string `json:"oauth_scopes,omitempty"`         // OAuth scopes to request
+	OauthTokenEndpoint string   `json:"oauth_token_endpoint,omitempty"` // The OAuth token endpoint URL
+}
+
+type RetryPolicy struct {
+	MaxAttempts          *int     `json:"max_attempts,omitempty"`           // Defaults to 3. The maximum number of attempts per request, including the first one. Set to 1 to disable retries.
+	MaxBackoff           *float64 `json:"max_backoff,omitempty"`            // Defaults to 30. The upper bound in seconds for the delay between two attempts.
+	MinBackoff           *float64 `json:"min_backoff,omitempty"`            // Defaults to 1. The delay in seconds before the first retry. The delay is doubled for every further retry.
+	RetryNonIdempotent   *bool    `json:"retry_non_idempotent,omitempty"`   // Defaults to false. Whether requests with non-idempotent methods, such as POST and PATCH, may be retried.
+	RetryableStatusCodes []int    `json:"retryable_status_codes,omitempty"` // Defaults to [429, 502, 503, 504]. Responses with these status codes are retried. Failed connections, such as connection resets, are retried as well.
+}
+
+type ProviderIdentity struct {
+	Description      string `json:"description,omitempty"`       // A Markdown description of the provider, shown in the generated documentation.
+	ModulePath       string `json:"module_path,omitempty"`       // Defaults to github.com/{namespace}/terraform-provider-{name}, with the name in kebab-case. The Go module path of the generated provider.
+	Name             string `json:"name"`                        // The name of the provider, in snake_case. It is used in the provider type name, the binary name and the environment variable names. Example: 'pet_store'.
+	Namespace        string `json:"namespace"`                   // The namespace of the provider in the registry, usually the name of the organization or author publishing it.
+	RegistryHostname string `json:"registry_hostname,omitempty"` // Defaults to registry.terraform.io. The hostname of the registry the provider is published to, such as registry.opentofu.org for OpenTofu.
 }
 
 type ResourcesSchema struct {
@@ -25,13 +90,14 @@ type ResourcesSchema struct {
 }
 
 type ResourceSchema struct {
-	GenerateDataSource *bool `json:"generate_data_source,omitempty"` // Defaults to true. Whether to generate a Terraform data source type for this API object.
-	GenerateResource   *bool `json:"generate_resource,omitempty"`    // Defaults to true. Whether to generate a Terraform resource type for this API object.
+	GenerateDataSource *bool                        `json:"generate_data_source,omitempty"` // Defaults to true. Whether to generate a Terraform data source type for this API object.
+	GenerateResource   *bool                        `json:"generate_resource,omitempty"`    // Defaults to true. Whether to generate a Terraform resource type for this API object.
+	Attributes         map[string]AttributeSettings `json:"attributes,omitempty"`           // Settings of individual attributes of the resource, keyed by the dot-separated path of their property in the bodies, such as 'metadata.timestamp'. By default, attributes required by the create request are Required, other attributes sent in requests are Optional, and attributes that are only returned in responses, or marked readOnly, are Computed. Optional attributes returned in responses are Computed as well, unless they are marked writeOnly. Optional attributes with a default value in the OpenAPI document are Computed too, and take that value if they are not set. Attributes of data sources are Computed, except for the id_attribute, which is Required.
 	Create             *struct {
 		Method string `json:"method,omitempty"` // Defaults to global {create_method}. Allows per-resource override of create_method (see create_method config documentation)
 		Path   string `json:"path,omitempty"`   // Defaults to {path}. The API path that represents where to CREATE (POST) objects of this type on the API server. The string {id} will be replaced with the terraform ID of the object if the data contains the id_attribute.
 	} `json:"create,omitempty"`
-	Debug   bool `json:"debug,omitempty"` // Whether to emit verbose debug output while working with the API object on the server.
+	Debug   bool `json:"debug,omitempty"` // Whether to log the requests and responses while working with the API object on the server through tflog at DEBUG level, which is shown with TF_LOG=DEBUG. Credentials and sensitive values are redacted.
 	Destroy *struct {
 		Method string `json:"method,omitempty"` // Defaults to global {destroy_method}. Allows per-resource override of create_method (see create_method config documentation)
 		Path   string `json:"path,omitempty"`   // Defaults to {path}. The API path that represents where to CREATE (POST) objects of this type on the API server. The string {id} will be replaced with the terraform ID of the object if the data contains the id_attribute.
@@ -42,6 +108,8 @@ type ResourceSchema struct {
 	IdAttributePath        string   `json:"id_attribute_path,omitempty"`         // Defaults to {id_attribute}. The string '{id_attribute_path}' in the path names is replaced with the object ID. Use this in combination with `id_attribute` if the name of the ID attribute differs in the body schemas compared to path variables.
 	IgnoreAllServerChanges bool     `json:"ignore_all_server_changes,omitempty"` // By default, Terraform will attempt to revert changes to remote resources. Set this to 'true' to ignore any remote changes. Default: false
 	IgnoreChangesTo        []string `json:"ignore_changes_to,omitempty"`         // A list of fields to which remote changes will be ignored. For example, an API might add or remove metadata, such as a 'last_modified' field, which Terraform should not attempt to correct. To ignore changes to nested fields, use the dot syntax: 'metadata.timestamp'
+	MaxNestingDepth        *int     `json:"max_nesting_depth,omitempty"`         // Defaults to global {max_nesting_depth}. Allows per-resource override of max_nesting_depth (see max_nesting_depth config documentation).
+	NestingFallback        *string  `json:"nesting_fallback,omitempty"`          // Defaults to global {nesting_fallback}. Allows per-resource override of nesting_fallback (see nesting_fallback config documentation).
 	ObjectId               string   `json:"object_id,omitempty"`                 // Defaults to the id learned by the provider during normal operations and id_attribute. Allows you to set the id manually. This is used in conjunction with the *_path attributes.
 	Path                   string   `json:"path"`                                // The API path on top of the base URL set in the provider that represents objects of this type on the API server.
 	QueryString            string   `json:"query_string,omitempty"`              // Query string to be included in the path
@@ -56,8 +124,13 @@ type ResourceSchema struct {
 			SearchValue string `json:"search_value"`           // The value of 'search_key' will be compared to this value to determine if the correct object was found. Example: if 'search_key' is 'name' and 'search_value' is 'foo', the record in the array returned by the API with name=foo will be used.
 		} `json:"search,omitempty"` // Custom search for read_path.
 	} `json:"read,omitempty"`
-	Update *struct {
+	RequestValidation *string      `json:"request_validation,omitempty"`  // Defaults to global {request_validation}. Allows per-resource override of request_validation (see request_validation config documentation).
+	ResponseObjectKey *string      `json:"response_object_key,omitempty"` // Defaults to global {response_object_key}. Allows per-resource override of response_object_key (see response_object_key config documentation). Set to an empty string to disable the global setting for this resource.
+	Retry             *RetryPolicy `json:"retry,omitempty"`               // Allows per-resource override of the retry policy (see retry config documentation). Unset values are taken from the global retry policy.
+	Sensitive         []string     `json:"sensitive,omitempty"`           // Dot-separated paths of properties whose attributes are sensitive, such as 'credentials.token'. Sensitive values are hidden in the plan output and redacted in logs. Properties with the password format, marked writeOnly or with the extension x-sensitive: true are sensitive without being listed.
+	Update            *struct {
 		Method string `json:"method,omitempty"` // Defaults to global {update_method}. Allows per-resource override of create_method (see create_method config documentation)
 		Path   string `json:"path,omitempty"`   // Defaults to {path}. The API path that represents where to CREATE (POST) objects of this type on the API server. The string {id} will be replaced with the terraform ID of the object if the data contains the id_attribute.
 	} `json:"update,omitempty"`
+	XssiPrefix *string `json:"xssi_prefix,omitempty"` // Defaults to global {xssi_prefix}. Allows per-resource override of xssi_prefix (see xssi_prefix config documentation). Set to an empty string to disable the global setting for this resource.
 }
@@ -36,7 +36,7 @@ func (r *ResourceSchema) GetOperationPath(operation RESTOperation, defaults *Glo
 			path = r.Destroy.Path
 		}
 	default:
-		log.Panicf(fmt.Sprintf("%s is not a valid REST operation", operation.name))
+		log.Panicf("%s is not a valid REST operation", operation.name)
 	}
 	if path == "" {
 		if operation == Create {
@@ -72,7 +72,7 @@ func (r *ResourceSchema) GetOperationMethod(operation RESTOperation, defaults *G
 			method = r.Destroy.Method
 		}
 	default:
-		log.Panicf(fmt.Sprintf("%s is not a valid REST operation", operation.name))
+		log.Panicf("%s is not a valid REST operation", operation.name)
 	}
 	if method == "" {
 		switch operation {
@@ -85,8 +85,95 @@ func (r *ResourceSchema) GetOperationMethod(operation RESTOperation, defaults *G
 		case Delete:
 			method = defaults.DestroyMethod
 		default:
-			log.Panicf(fmt.Sprintf("%s is not a valid REST operation", operation.name))
+			log.Panicf("%s is not a valid REST operation", operation.name)
 		}
 	}
 	return method
 }
+
+// GetRetryPolicy returns the retry policy for the resource.
+// Values that are not overridden for the resource are taken from the global defaults.
+func (r *ResourceSchema) GetRetryPolicy(defaults *GlobalDefaults) RetryPolicy {
+	var policy RetryPolicy
+	if defaults.Retry != nil {
+		policy = *defaults.Retry
+	}
+	if r.Retry != nil {
+		if r.Retry.MaxAttempts != nil {
+			policy.MaxAttempts = r.Retry.MaxAttempts
+		}
+		if r.Retry.MinBackoff != nil {
+			policy.MinBackoff = r.Retry.MinBackoff
+		}
+		if r.Retry.MaxBackoff != nil {
+			policy.MaxBackoff = r.Retry.MaxBackoff
+		}
+		if r.Retry.RetryableStatusCodes != nil {
+			policy.RetryableStatusCodes = r.Retry.RetryableStatusCodes
+		}
+		if r.Retry.RetryNonIdempotent != nil {
+			policy.RetryNonIdempotent = r.Retry.RetryNonIdempotent
+		}
+	}
+	return policy
+}
+
+// GetXssiPrefix returns the XSSI prefix stripped from the responses for the resource.
+func (r *ResourceSchema) GetXssiPrefix(defaults *GlobalDefaults) string {
+	if r.XssiPrefix != nil {
+		return *r.XssiPrefix
+	}
+	return defaults.XssiPrefix
+}
+
+// GetResponseObjectKey returns the key from which the object is read in the responses for the resource.
+func (r *ResourceSchema) GetResponseObjectKey(defaults *GlobalDefaults) string {
+	if r.ResponseObjectKey != nil {
+		return *r.ResponseObjectKey
+	}
+	return defaults.ResponseObjectKey
+}
+
+const (
+	NestingFallbackDynamic    = "dynamic"
+	NestingFallbackJSONString = "json_string"
+)
+
+// GetMaxNestingDepth returns the maximum number of levels of nested attributes generated for a property of the resource.
+func (r *ResourceSchema) GetMaxNestingDepth(defaults *GlobalDefaults) int {
+	if r.MaxNestingDepth != nil {
+		return *r.MaxNestingDepth
+	}
+	if defaults.MaxNestingDepth > 0 {
+		return defaults.MaxNestingDepth
+	}
+	return 5
+}
+
+// GetNestingFallback returns the type of the attributes generated for objects of the resource that are nested too deeply.
+func (r *ResourceSchema) GetNestingFallback(defaults *GlobalDefaults) string {
+	if r.NestingFallback != nil {
+		return *r.NestingFallback
+	}
+	if defaults.NestingFallback != "" {
+		return defaults.NestingFallback
+	}
+	return NestingFallbackDynamic
+}
+
+const (
+	RequestValidationError   = "error"
+	RequestValidationWarning = "warning"
+	RequestValidationOff     = "off"
+)
+
+// GetRequestValidation returns how request bodies of the resource that do not match the OpenAPI document are reported.
+func (r *ResourceSchema) GetRequestValidation(defaults *GlobalDefaults) string {
+	if r.RequestValidation != nil {
+		return *r.RequestValidation
+	}
+	if defaults.RequestValidation != "" {
+		return defaults.RequestValidation
+	}
+	return RequestValidationError
+}